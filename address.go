@@ -1,6 +1,7 @@
 package namecheap
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -44,21 +45,34 @@ type AddressGetInfoResult struct {
 }
 
 func (client *Client) AddressGetList() ([]AddressGetListResult, error) {
+	return client.AddressGetListContext(context.Background())
+}
+
+// AddressGetListContext is like AddressGetList but takes a context.
+func (client *Client) AddressGetListContext(ctx context.Context) ([]AddressGetListResult, error) {
 	requestInfo := &ApiRequest{
 		command: addressGetList,
 		method:  "POST",
 		params:  url.Values{},
 	}
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		AddressGetList []AddressGetListResult `xml:"AddressGetListResult>List"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.AddressGetList, nil
+	return result.AddressGetList, err
 }
 
 func (client *Client) AddressGetInfo(addressID int) (*AddressGetInfoResult, error) {
+	return client.AddressGetInfoContext(context.Background(), addressID)
+}
+
+// AddressGetInfoContext is like AddressGetInfo but takes a context.
+func (client *Client) AddressGetInfoContext(ctx context.Context, addressID int) (*AddressGetInfoResult, error) {
 	requestInfo := &ApiRequest{
 		command: addressGetInfo,
 		method:  "POST",
@@ -67,10 +81,13 @@ func (client *Client) AddressGetInfo(addressID int) (*AddressGetInfoResult, erro
 
 	requestInfo.params.Set("AddressId", fmt.Sprintf("%d", addressID))
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		AddressGetInfo *AddressGetInfoResult `xml:"GetAddressInfoResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.AddressGetInfo, nil
+	return result.AddressGetInfo, err
 }
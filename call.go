@@ -0,0 +1,49 @@
+package namecheap
+
+import (
+	"context"
+	"net/url"
+)
+
+// Call sends any Namecheap command, including ones this package does not
+// wrap, and unmarshals its CommandResponse element into out (which may be
+// nil). The fields of out are matched against the children of
+// CommandResponse, e.g.
+//
+//	var out struct {
+//		Result struct {
+//			Domain   string `xml:"Domain,attr"`
+//			Forwards []struct {
+//				Mailbox string `xml:"mailbox,attr"`
+//				To      string `xml:",chardata"`
+//			} `xml:"Forward"`
+//		} `xml:"DomainDNSGetEmailForwardingResult"`
+//	}
+//	err := client.Call("namecheap.domains.dns.getEmailForwarding",
+//		url.Values{"DomainName": {"example.com"}}, &out)
+//
+// Authentication, ClientIp, retries, rate limiting, middleware and
+// error handling are the same as for the wrapped commands, and domain names
+// in the DomainName, DomainList, SLD and TLD parameters are converted with
+// ToASCII too. As Call cannot tell whether a command charges the account,
+// commands not wrapped by this package are only retried when they read
+// data, i.e. when their name ends in get... or check, unless the failed
+// attempt provably did not reach the API.
+func (client *Client) Call(command string, params url.Values, out interface{}) error {
+	return client.CallContext(context.Background(), command, params, out)
+}
+
+// CallContext is like Call but takes a context.
+func (client *Client) CallContext(ctx context.Context, command string, params url.Values, out interface{}) error {
+	requestInfo := &ApiRequest{
+		command: command,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	for k, v := range params {
+		requestInfo.params[k] = v
+	}
+
+	_, err := client.do(ctx, requestInfo, out)
+	return err
+}
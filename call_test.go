@@ -0,0 +1,66 @@
+package namecheap
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCall(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.getRegistrarLock</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getRegistrarLock">
+    <DomainGetRegistrarLockResult Domain="domain.com" RegistrarLockStatus="true" />
+  </CommandResponse>
+  <Server>SERVER-NAME</Server>
+  <GMTTimeDifference>+5</GMTTimeDifference>
+  <ExecutionTime>32.76</ExecutionTime>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getRegistrarLock")
+		correctParams.Set("DomainName", "domain.com")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	var out struct {
+		Result struct {
+			Domain string `xml:"Domain,attr"`
+			Locked bool   `xml:"RegistrarLockStatus,attr"`
+		} `xml:"DomainGetRegistrarLockResult"`
+	}
+	err := client.Call("namecheap.domains.getRegistrarLock", url.Values{"DomainName": {"domain.com"}}, &out)
+	if err != nil {
+		t.Fatalf("Call returned error: %v", err)
+	}
+	if out.Result.Domain != "domain.com" || !out.Result.Locked {
+		t.Errorf("Call decoded %+v", out.Result)
+	}
+}
+
+func TestCallError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+			<Errors>
+				<Error Number="2019166">Domain not found</Error>
+			</Errors>
+		</ApiResponse>`)
+	})
+
+	var out struct{}
+	if err := client.Call("namecheap.domains.getRegistrarLock", nil, &out); err == nil {
+		t.Error("Expected error from Call")
+	}
+}
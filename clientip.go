@@ -0,0 +1,156 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// defaultClientIP is sent as ClientIp when neither Client.ClientIP nor
+// Client.IPResolver are set.
+const defaultClientIP = "127.0.0.1"
+
+// DefaultIPEchoURL is a public service answering with the caller's IP address
+// as plain text.
+const DefaultIPEchoURL = "https://api.ipify.org"
+
+// IPResolver discovers the public IP address outbound requests come from.
+type IPResolver interface {
+	ResolveIP(ctx context.Context) (net.IP, error)
+}
+
+// HTTPIPResolver resolves the public IP address by asking an HTTP echo
+// service which replies with the address as plain text.
+type HTTPIPResolver struct {
+	// URL of the echo service. Defaults to DefaultIPEchoURL.
+	URL string
+	// HttpClient used to reach the echo service. Defaults to
+	// http.DefaultClient; use the same client as the Namecheap Client when
+	// outbound connections are bound to a specific local address.
+	HttpClient *http.Client
+}
+
+// ResolveIP implements IPResolver.
+func (resolver *HTTPIPResolver) ResolveIP(ctx context.Context) (net.IP, error) {
+	echoURL := resolver.URL
+	if echoURL == "" {
+		echoURL = DefaultIPEchoURL
+	}
+	httpClient := resolver.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", echoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, fmt.Errorf("ip echo service returned an invalid address: %q", body)
+	}
+	return ip, nil
+}
+
+// NewHTTPClientWithLocalAddr returns an *http.Client whose outbound
+// connections originate from the given local IP address, for hosts with
+// several addresses where only one is whitelisted at Namecheap.
+func NewHTTPClientWithLocalAddr(localIP string) (*http.Client, error) {
+	ip := net.ParseIP(localIP)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid local address: %q", localIP)
+	}
+
+	dialer := &net.Dialer{
+		LocalAddr: &net.TCPAddr{IP: ip},
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Transport: transport}, nil
+}
+
+// ClientIPError is returned in place of the API error when Namecheap rejects
+// the client IP. It reports the address the client sent next to the one
+// Namecheap saw, when the API mentions it.
+type ClientIPError struct {
+	// ClientIP is the ClientIp parameter that was sent.
+	ClientIP string
+	// RequestIP is the address Namecheap reported, if any.
+	RequestIP string
+
+	Err error
+}
+
+func (err *ClientIPError) Error() string {
+	msg := fmt.Sprintf("namecheap rejected client IP %s", err.ClientIP)
+	if err.RequestIP != "" && err.RequestIP != err.ClientIP {
+		msg += fmt.Sprintf(" (request came from %s)", err.RequestIP)
+	}
+	return msg + "; check the IP whitelist of the API account: " + strings.TrimSpace(err.Err.Error())
+}
+
+func (err *ClientIPError) Unwrap() error {
+	return err.Err
+}
+
+// clientIP returns the ClientIp parameter to send, resolving and caching it
+// with the IPResolver when no ClientIP is configured.
+func (client *Client) clientIP(ctx context.Context) (string, error) {
+	if client.ClientIP != "" {
+		return client.ClientIP, nil
+	}
+	if client.IPResolver == nil {
+		return defaultClientIP, nil
+	}
+
+	client.ipMu.Lock()
+	defer client.ipMu.Unlock()
+	if client.resolvedIP == "" {
+		ip, err := client.IPResolver.ResolveIP(ctx)
+		if err != nil {
+			return "", fmt.Errorf("resolving client IP: %w", err)
+		}
+		client.resolvedIP = ip.String()
+	}
+	return client.resolvedIP, nil
+}
+
+// diagnoseClientIP turns an IP whitelist rejection into a ClientIPError.
+func diagnoseClientIP(err error, clientIP string) error {
+	if !errors.Is(err, ErrIPNotWhitelisted) {
+		return err
+	}
+
+	diag := &ClientIPError{ClientIP: clientIP, Err: err}
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		for _, field := range strings.Fields(apiErr.Message) {
+			if ip := net.ParseIP(strings.Trim(field, `.,;:()[]"'`)); ip != nil {
+				diag.RequestIP = ip.String()
+				break
+			}
+		}
+	}
+	return diag
+}
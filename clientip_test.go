@@ -0,0 +1,112 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const okResponseXML = `<?xml version="1.0" encoding="utf-8"?><ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response"></ApiResponse>`
+
+func TestClientIPConfigured(t *testing.T) {
+	setup()
+	defer teardown()
+	client.ClientIP = "203.0.113.7"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if ip := r.FormValue("ClientIp"); ip != "203.0.113.7" {
+			t.Errorf("ClientIp = %v, want 203.0.113.7", ip)
+		}
+		fmt.Fprint(w, okResponseXML)
+	})
+
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+}
+
+func TestClientIPResolved(t *testing.T) {
+	setup()
+	defer teardown()
+
+	lookups := 0
+	echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		fmt.Fprintln(w, "198.51.100.23")
+	}))
+	defer echo.Close()
+	client.IPResolver = &HTTPIPResolver{URL: echo.URL}
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if ip := r.FormValue("ClientIp"); ip != "198.51.100.23" {
+			t.Errorf("ClientIp = %v, want 198.51.100.23", ip)
+		}
+		fmt.Fprint(w, okResponseXML)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.DomainGetInfo("example.com"); err != nil {
+			t.Errorf("DomainGetInfo returned error: %v", err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("Expected the IP to be resolved once, got %d lookups", lookups)
+	}
+}
+
+func TestClientIPRejected(t *testing.T) {
+	setup()
+	defer teardown()
+	client.ClientIP = "203.0.113.7"
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+			<Errors>
+				<Error Number="1011150">Invalid request IP: 198.51.100.23</Error>
+			</Errors>
+		</ApiResponse>`)
+	})
+
+	_, err := client.DomainGetInfo("example.com")
+	var ipErr *ClientIPError
+	if !errors.As(err, &ipErr) {
+		t.Fatalf("Expected ClientIPError, got %v", err)
+	}
+	if ipErr.ClientIP != "203.0.113.7" || ipErr.RequestIP != "198.51.100.23" {
+		t.Errorf("ClientIPError = %+v", ipErr)
+	}
+	if !strings.Contains(err.Error(), "198.51.100.23") {
+		t.Errorf("Expected the error to mention the request IP, got %q", err)
+	}
+	if !errors.Is(err, ErrIPNotWhitelisted) {
+		t.Errorf("Expected ErrIPNotWhitelisted, got %v", err)
+	}
+}
+
+func TestNewHTTPClientWithLocalAddr(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.RemoteAddr, "127.0.0.1:") {
+			t.Errorf("RemoteAddr = %v, want 127.0.0.1", r.RemoteAddr)
+		}
+		fmt.Fprint(w, okResponseXML)
+	})
+
+	httpClient, err := NewHTTPClientWithLocalAddr("127.0.0.1")
+	if err != nil {
+		t.Fatalf("NewHTTPClientWithLocalAddr returned error: %v", err)
+	}
+	client.HttpClient = httpClient
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+
+	if _, err := NewHTTPClientWithLocalAddr("not-an-ip"); err == nil {
+		t.Error("Expected error for invalid local address")
+	}
+}
@@ -0,0 +1,171 @@
+package namecheap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv and NewClientFromEnv.
+const (
+	EnvConfigFile = "NAMECHEAP_CONFIG"
+	EnvProfile    = "NAMECHEAP_PROFILE"
+	EnvApiUser    = "NAMECHEAP_API_USER"
+	EnvApiKey     = "NAMECHEAP_API_KEY"
+	EnvUserName   = "NAMECHEAP_USERNAME"
+	EnvClientIP   = "NAMECHEAP_CLIENT_IP"
+	EnvSandbox    = "NAMECHEAP_SANDBOX"
+	EnvBaseURL    = "NAMECHEAP_BASE_URL"
+	EnvUserAgent  = "NAMECHEAP_USER_AGENT"
+	EnvTimeout    = "NAMECHEAP_TIMEOUT"
+)
+
+// Config holds the settings of one Namecheap account.
+// In a config file it is one entry of the "profiles" object:
+//
+//	{
+//	  "profiles": {
+//	    "prod":    {"api_user": "acme", "api_key": "...", "client_ip": "203.0.113.7"},
+//	    "sandbox": {"api_user": "acme", "api_key": "...", "sandbox": true, "timeout": "30s"}
+//	  }
+//	}
+type Config struct {
+	ApiUser   string `json:"api_user"`
+	ApiKey    string `json:"api_key"`
+	UserName  string `json:"username"`
+	ClientIP  string `json:"client_ip"`
+	Sandbox   bool   `json:"sandbox"`
+	BaseURL   string `json:"base_url"`
+	UserAgent string `json:"user_agent"`
+	Timeout   string `json:"timeout"`
+}
+
+type configFile struct {
+	Profiles map[string]Config `json:"profiles"`
+}
+
+// DefaultConfigPath returns the config file used when NAMECHEAP_CONFIG is not
+// set: namecheap/config.json in the user's configuration directory.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "namecheap", "config.json"), nil
+}
+
+// LoadConfigProfile reads the named profile from the config file at path.
+func LoadConfigProfile(path, profile string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var file configFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return Config{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	cfg, ok := file.Profiles[profile]
+	if !ok {
+		return Config{}, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+	return cfg, nil
+}
+
+// ConfigFromEnv builds a Config from the environment. If NAMECHEAP_PROFILE is
+// set, that profile is loaded from NAMECHEAP_CONFIG (or DefaultConfigPath)
+// first, and the other variables override its values.
+func ConfigFromEnv() (Config, error) {
+	var cfg Config
+	if profile := os.Getenv(EnvProfile); profile != "" {
+		path := os.Getenv(EnvConfigFile)
+		if path == "" {
+			var err error
+			if path, err = DefaultConfigPath(); err != nil {
+				return Config{}, err
+			}
+		}
+
+		var err error
+		if cfg, err = LoadConfigProfile(path, profile); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for env, field := range map[string]*string{
+		EnvApiUser:   &cfg.ApiUser,
+		EnvApiKey:    &cfg.ApiKey,
+		EnvUserName:  &cfg.UserName,
+		EnvClientIP:  &cfg.ClientIP,
+		EnvBaseURL:   &cfg.BaseURL,
+		EnvUserAgent: &cfg.UserAgent,
+		EnvTimeout:   &cfg.Timeout,
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			*field = v
+		}
+	}
+	if v, ok := os.LookupEnv(EnvSandbox); ok {
+		sandbox, err := strconv.ParseBool(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("%s: %w", EnvSandbox, err)
+		}
+		cfg.Sandbox = sandbox
+	}
+
+	return cfg, nil
+}
+
+// NewClient returns a client configured by cfg. Options are applied after
+// the ones derived from cfg, so they take precedence. The timeout of cfg is
+// applied last, to the HTTP client set by the options if any, unless that
+// client already has a timeout of its own.
+func (cfg Config) NewClient(opts ...ClientOption) (*Client, error) {
+	if cfg.ApiUser == "" || cfg.ApiKey == "" {
+		return nil, errors.New("api user and api key are required")
+	}
+	userName := cfg.UserName
+	if userName == "" {
+		userName = cfg.ApiUser
+	}
+
+	var cfgOpts []ClientOption
+	if cfg.Sandbox {
+		cfgOpts = append(cfgOpts, WithSandbox())
+	}
+	if cfg.BaseURL != "" {
+		cfgOpts = append(cfgOpts, WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.ClientIP != "" {
+		cfgOpts = append(cfgOpts, WithClientIP(cfg.ClientIP))
+	}
+	if cfg.UserAgent != "" {
+		cfgOpts = append(cfgOpts, WithUserAgent(cfg.UserAgent))
+	}
+	var timeout time.Duration
+	if cfg.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("timeout: %w", err)
+		}
+	}
+
+	client := NewClient(cfg.ApiUser, cfg.ApiKey, userName, append(cfgOpts, opts...)...)
+	if timeout != 0 && (client.HttpClient == nil || client.HttpClient.Timeout == 0) {
+		WithTimeout(timeout)(client)
+	}
+	return client, nil
+}
+
+// NewClientFromEnv returns a client configured by ConfigFromEnv.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient(opts...)
+}
@@ -0,0 +1,84 @@
+package namecheap
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(path, []byte(`{
+  "profiles": {
+    "prod": {"api_user": "acme", "api_key": "prodKey", "client_ip": "203.0.113.7"},
+    "sandbox": {"api_user": "acme", "api_key": "sandboxKey", "sandbox": true, "timeout": "30s"}
+  }
+}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigProfile(t *testing.T) {
+	path := writeConfig(t)
+
+	cfg, err := LoadConfigProfile(path, "sandbox")
+	if err != nil {
+		t.Fatalf("LoadConfigProfile returned error: %v", err)
+	}
+	c, err := cfg.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if c.ApiToken != "sandboxKey" || c.UserName != "acme" || c.BaseURL != sandboxBaseURL {
+		t.Errorf("Unexpected client %+v", c)
+	}
+	if c.HttpClient.Timeout != 30*time.Second {
+		t.Errorf("Timeout = %v, want 30s", c.HttpClient.Timeout)
+	}
+
+	// The timeout survives the caller's own HTTP client, which is copied.
+	httpClient := &http.Client{}
+	if c, err = cfg.NewClient(WithHTTPClient(httpClient)); err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if c.HttpClient.Timeout != 30*time.Second || httpClient.Timeout != 0 {
+		t.Errorf("Timeout = %v with the caller's client, want 30s on a copy", c.HttpClient.Timeout)
+	}
+	if c, err = cfg.NewClient(WithHTTPClient(nil), WithTimeout(time.Second)); err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if c.HttpClient.Timeout != time.Second {
+		t.Errorf("Timeout = %v after WithTimeout, want 1s", c.HttpClient.Timeout)
+	}
+
+	if _, err := LoadConfigProfile(path, "reseller"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvConfigFile, writeConfig(t))
+	t.Setenv(EnvProfile, "prod")
+	t.Setenv(EnvUserName, "acme-reseller")
+
+	c, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv returned error: %v", err)
+	}
+	if c.ApiToken != "prodKey" || c.ClientIP != "203.0.113.7" || c.BaseURL != defaultBaseURL {
+		t.Errorf("Unexpected client %+v", c)
+	}
+	if c.UserName != "acme-reseller" {
+		t.Errorf("UserName = %v, want the environment to override the profile", c.UserName)
+	}
+
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvApiUser, "")
+	if _, err := NewClientFromEnv(); err == nil {
+		t.Error("Expected error without credentials")
+	}
+}
@@ -0,0 +1,106 @@
+package namecheap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the formats the API uses for dates and timestamps.
+var dateLayouts = []string{
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/2006 15:04:05",
+	"1/2/2006 3:04:05 PM",
+	"01/02/2006 03:04:05 PM",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+// Date is a date or timestamp returned by the API. Namecheap sends them in
+// the time zone of its servers without saying which one it is, so Date holds
+// the wall clock reading in UTC; use In with ResponseMeta.GMTOffset to place
+// it in the server's zone.
+type Date struct {
+	time.Time
+}
+
+// ParseDate parses a date in any of the formats used by the API, such as
+// "11/04/2014" or "4/30/2021 11:31:13 AM". An empty string is the zero Date.
+func ParseDate(s string) (Date, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Date{}, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Date{t}, nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date %q", s)
+}
+
+func (d *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	parsed, err := ParseDate(attr.Value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d *Date) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := decoder.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// String formats the date the way the API does, MM/DD/YYYY.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format("01/02/2006")
+}
+
+// In returns the date's wall clock reading in the fixed zone offset from
+// GMT, typically ResponseMeta.GMTOffset.
+func (d Date) In(offset time.Duration) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), d.Hour(), d.Minute(), d.Second(), d.Nanosecond(),
+		time.FixedZone("", int(offset/time.Second)))
+}
+
+// DaysFrom returns the number of calendar days from the day of t to the
+// date, negative if the date is before t.
+func (d Date) DaysFrom(t time.Time) int {
+	from := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// DaysUntilExpiry returns the number of days until the domain expires,
+// negative if it already has.
+func (domain *DomainGetListResult) DaysUntilExpiry() int {
+	return domain.Expires.DaysFrom(time.Now())
+}
+
+// DaysUntilExpiry returns the number of days until the domain expires,
+// negative if it already has.
+func (info *DomainInfo) DaysUntilExpiry() int {
+	return info.Expires.DaysFrom(time.Now())
+}
+
+// DaysUntilExpiry returns the number of days until the Whoisguard
+// subscription expires, negative if it already has.
+func (wg *WhoisguardGetListResult) DaysUntilExpiry() int {
+	return wg.Expires.DaysFrom(time.Now())
+}
@@ -0,0 +1,71 @@
+package namecheap
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func mustDate(s string) Date {
+	d, err := ParseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParseDate(t *testing.T) {
+	cases := map[string]time.Time{
+		"11/04/2014":            time.Date(2014, 11, 4, 0, 0, 0, 0, time.UTC),
+		"4/3/2021":              time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC),
+		"4/30/2021 11:31:13 AM": time.Date(2021, 4, 30, 11, 31, 13, 0, time.UTC),
+		"2021-04-30T11:31:13":   time.Date(2021, 4, 30, 11, 31, 13, 0, time.UTC),
+		"":                      {},
+	}
+	for in, want := range cases {
+		got, err := ParseDate(in)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+
+	if _, err := ParseDate("yesterday"); err == nil {
+		t.Error("Expected error for an invalid date")
+	}
+}
+
+func TestDateXML(t *testing.T) {
+	var v struct {
+		Attr    Date `xml:"Expires,attr"`
+		Element Date `xml:"ExpiredDate"`
+	}
+	err := xml.Unmarshal([]byte(`<Domain Expires="11/04/2015"><ExpiredDate>12/18/2014</ExpiredDate></Domain>`), &v)
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if v.Attr.String() != "11/04/2015" || v.Element.String() != "12/18/2014" {
+		t.Errorf("Unmarshal returned %v and %v", v.Attr, v.Element)
+	}
+}
+
+func TestDateInAndDaysFrom(t *testing.T) {
+	d := mustDate("11/04/2015")
+
+	eastern := d.In(-5 * time.Hour)
+	if want := time.Date(2015, 11, 4, 5, 0, 0, 0, time.UTC); !eastern.Equal(want) {
+		t.Errorf("In(-5h) = %v, want %v", eastern, want)
+	}
+
+	now := time.Date(2015, 10, 5, 23, 59, 0, 0, time.UTC)
+	if days := d.DaysFrom(now); days != 30 {
+		t.Errorf("DaysFrom = %d, want 30", days)
+	}
+	if days := d.DaysFrom(now.AddDate(0, 2, 0)); days != -31 {
+		t.Errorf("DaysFrom = %d, want -31", days)
+	}
+
+	domain := DomainGetListResult{Expires: Date{time.Now().AddDate(0, 0, 10)}}
+	if days := domain.DaysUntilExpiry(); days != 10 {
+		t.Errorf("DaysUntilExpiry = %d, want 10", days)
+	}
+}
@@ -1,6 +1,8 @@
 package namecheap
 
 import (
+	"context"
+	"encoding/xml"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -27,12 +29,21 @@ type DomainDNSHost struct {
 	TTL     int    `xml:"TTL,attr"`
 }
 
+func (host *DomainDNSHost) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalAttrs(d, start, host)
+}
+
 type DomainDNSSetHostsResult struct {
 	Domain    string `xml:"Domain,attr"`
 	IsSuccess bool   `xml:"IsSuccess,attr"`
 }
 
 func (client *Client) DomainsDNSGetHosts(sld, tld string) (*DomainDNSGetHostsResult, error) {
+	return client.DomainsDNSGetHostsContext(context.Background(), sld, tld)
+}
+
+// DomainsDNSGetHostsContext is like DomainsDNSGetHosts but takes a context.
+func (client *Client) DomainsDNSGetHostsContext(ctx context.Context, sld, tld string) (*DomainDNSGetHostsResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsDNSGetHosts,
 		method:  "POST",
@@ -41,16 +52,44 @@ func (client *Client) DomainsDNSGetHosts(sld, tld string) (*DomainDNSGetHostsRes
 	requestInfo.params.Set("SLD", sld)
 	requestInfo.params.Set("TLD", tld)
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		DomainDNSHosts *DomainDNSGetHostsResult `xml:"DomainDNSGetHostsResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.DomainDNSHosts, nil
+	if result.DomainDNSHosts != nil {
+		result.DomainDNSHosts.Domain = unicodeDomain(result.DomainDNSHosts.Domain)
+	}
+	return result.DomainDNSHosts, err
+}
+
+// DomainsDNSGetHostsByName is like DomainsDNSGetHosts but takes a full
+// domain name, split with the client's DomainParser.
+func (client *Client) DomainsDNSGetHostsByName(domainName string) (*DomainDNSGetHostsResult, error) {
+	return client.DomainsDNSGetHostsByNameContext(context.Background(), domainName)
+}
+
+// DomainsDNSGetHostsByNameContext is like DomainsDNSGetHostsByName but takes a context.
+func (client *Client) DomainsDNSGetHostsByNameContext(ctx context.Context, domainName string) (*DomainDNSGetHostsResult, error) {
+	name, err := client.DomainParser.Parse(domainName)
+	if err != nil {
+		return nil, err
+	}
+	return client.DomainsDNSGetHostsContext(ctx, name.SLD, name.TLD)
 }
 
 func (client *Client) DomainDNSSetHosts(
 	sld, tld string, hosts []DomainDNSHost,
+) (*DomainDNSSetHostsResult, error) {
+	return client.DomainDNSSetHostsContext(context.Background(), sld, tld, hosts)
+}
+
+// DomainDNSSetHostsContext is like DomainDNSSetHosts but takes a context.
+func (client *Client) DomainDNSSetHostsContext(
+	ctx context.Context, sld, tld string, hosts []DomainDNSHost,
 ) (*DomainDNSSetHostsResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsDNSSetHosts,
@@ -71,11 +110,34 @@ func (client *Client) DomainDNSSetHosts(
 		requestInfo.params.Set(fmt.Sprintf("TTL%v", i+1), strconv.Itoa(h.TTL))
 	}
 
-	resp, err := client.do(requestInfo)
+	var result struct {
+		DomainDNSSetHosts *DomainDNSSetHostsResult `xml:"DomainDNSSetHostsResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+	if result.DomainDNSSetHosts != nil {
+		result.DomainDNSSetHosts.Domain = unicodeDomain(result.DomainDNSSetHosts.Domain)
+	}
+	return result.DomainDNSSetHosts, err
+}
+
+// DomainDNSSetHostsByName is like DomainDNSSetHosts but takes a full domain
+// name, split with the client's DomainParser.
+func (client *Client) DomainDNSSetHostsByName(domainName string, hosts []DomainDNSHost) (*DomainDNSSetHostsResult, error) {
+	return client.DomainDNSSetHostsByNameContext(context.Background(), domainName, hosts)
+}
+
+// DomainDNSSetHostsByNameContext is like DomainDNSSetHostsByName but takes a context.
+func (client *Client) DomainDNSSetHostsByNameContext(
+	ctx context.Context, domainName string, hosts []DomainDNSHost,
+) (*DomainDNSSetHostsResult, error) {
+	name, err := client.DomainParser.Parse(domainName)
 	if err != nil {
 		return nil, err
 	}
-	return resp.DomainDNSSetHosts, nil
+	return client.DomainDNSSetHostsContext(ctx, name.SLD, name.TLD, hosts)
 }
 
 type DomainDNSSetCustomResult struct {
@@ -84,6 +146,11 @@ type DomainDNSSetCustomResult struct {
 }
 
 func (client *Client) DomainDNSSetCustom(sld, tld, nameservers string) (*DomainDNSSetCustomResult, error) {
+	return client.DomainDNSSetCustomContext(context.Background(), sld, tld, nameservers)
+}
+
+// DomainDNSSetCustomContext is like DomainDNSSetCustom but takes a context.
+func (client *Client) DomainDNSSetCustomContext(ctx context.Context, sld, tld, nameservers string) (*DomainDNSSetCustomResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsDNSSetCustom,
 		method:  "POST",
@@ -93,9 +160,30 @@ func (client *Client) DomainDNSSetCustom(sld, tld, nameservers string) (*DomainD
 	requestInfo.params.Set("TLD", tld)
 	requestInfo.params.Set("Nameservers", nameservers)
 
-	resp, err := client.do(requestInfo)
+	var result struct {
+		DomainDNSSetCustom *DomainDNSSetCustomResult `xml:"DomainDNSSetCustomResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+	if result.DomainDNSSetCustom != nil {
+		result.DomainDNSSetCustom.Domain = unicodeDomain(result.DomainDNSSetCustom.Domain)
+	}
+	return result.DomainDNSSetCustom, err
+}
+
+// DomainDNSSetCustomByName is like DomainDNSSetCustom but takes a full domain
+// name, split with the client's DomainParser.
+func (client *Client) DomainDNSSetCustomByName(domainName, nameservers string) (*DomainDNSSetCustomResult, error) {
+	return client.DomainDNSSetCustomByNameContext(context.Background(), domainName, nameservers)
+}
+
+// DomainDNSSetCustomByNameContext is like DomainDNSSetCustomByName but takes a context.
+func (client *Client) DomainDNSSetCustomByNameContext(ctx context.Context, domainName, nameservers string) (*DomainDNSSetCustomResult, error) {
+	name, err := client.DomainParser.Parse(domainName)
 	if err != nil {
 		return nil, err
 	}
-	return resp.DomainDNSSetCustom, nil
+	return client.DomainDNSSetCustomContext(ctx, name.SLD, name.TLD, nameservers)
 }
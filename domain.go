@@ -1,7 +1,9 @@
 package namecheap
 
 import (
-	"errors"
+	"context"
+	"encoding/xml"
+	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
@@ -14,7 +16,12 @@ const (
 	domainsCreate      = "namecheap.domains.create"
 	domainsTLDList     = "namecheap.domains.getTldList"
 	domainsRenew       = "namecheap.domains.renew"
+	domainsReactivate  = "namecheap.domains.reactivate"
 	domainsGetContacts = "namecheap.domains.getContacts"
+	domainsSetContacts = "namecheap.domains.setContacts"
+
+	domainsGetRegistrarLock = "namecheap.domains.getRegistrarLock"
+	domainsSetRegistrarLock = "namecheap.domains.setRegistrarLock"
 )
 
 // DomainGetListResult represents the data returned by 'domains.getList'
@@ -22,8 +29,8 @@ type DomainGetListResult struct {
 	ID         int    `xml:"ID,attr"`
 	Name       string `xml:"Name,attr"`
 	User       string `xml:"User,attr"`
-	Created    string `xml:"Created,attr"`
-	Expires    string `xml:"Expires,attr"`
+	Created    Date   `xml:"Created,attr"`
+	Expires    Date   `xml:"Expires,attr"`
 	IsExpired  bool   `xml:"IsExpired,attr"`
 	IsLocked   bool   `xml:"IsLocked,attr"`
 	IsPremium  bool   `xml:"IsPremium,attr"`
@@ -32,6 +39,10 @@ type DomainGetListResult struct {
 	WhoisGuard string `xml:"WhoisGuard,attr"`
 }
 
+func (result *DomainGetListResult) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalAttrs(d, start, result)
+}
+
 // DomainInfo represents the data returned by 'domains.getInfo'
 type DomainInfo struct {
 	ID         int        `xml:"ID,attr"`
@@ -39,8 +50,8 @@ type DomainInfo struct {
 	Owner      string     `xml:"OwnerName,attr"`
 	IsOwner    bool       `xml:"IsOwner,attr"`
 	IsPremium  bool       `xml:"IsPremium,attr"`
-	Created    string     `xml:"DomainDetails>CreatedDate"`
-	Expires    string     `xml:"DomainDetails>ExpiredDate"`
+	Created    Date       `xml:"DomainDetails>CreatedDate"`
+	Expires    Date       `xml:"DomainDetails>ExpiredDate"`
 	IsExpired  bool       `xml:"IsExpired,attr"`
 	IsLocked   bool       `xml:"IsLocked,attr"`
 	AutoRenew  bool       `xml:"AutoRenew,attr"`
@@ -57,18 +68,18 @@ type DNSDetails struct {
 type Whoisguard struct {
 	Enabled     string `xml:"Enabled,attr"`
 	ID          int64  `xml:"ID"`
-	ExpiredDate string `xml:"ExpiredDate"`
+	ExpiredDate Date   `xml:"ExpiredDate"`
 }
 
 type DomainCheckResult struct {
-	Domain                   string  `xml:"Domain,attr"`
-	Available                bool    `xml:"Available,attr"`
-	IsPremiumName            bool    `xml:"IsPremiumName,attr"`
-	PremiumRegistrationPrice float64 `xml:"PremiumRegistrationPrice,attr"`
-	PremiumRenewalPrice      float64 `xml:"PremiumRenewalPrice,attr"`
-	PremiumRestorePrice      float64 `xml:"PremiumRestorePrice,attr"`
-	PremiumTransferPrice     float64 `xml:"PremiumTransferPrice,attr"`
-	IcannFee                 float64 `xml:"IcannFee,attr"`
+	Domain                   string `xml:"Domain,attr"`
+	Available                bool   `xml:"Available,attr"`
+	IsPremiumName            bool   `xml:"IsPremiumName,attr"`
+	PremiumRegistrationPrice Money  `xml:"PremiumRegistrationPrice,attr"`
+	PremiumRenewalPrice      Money  `xml:"PremiumRenewalPrice,attr"`
+	PremiumRestorePrice      Money  `xml:"PremiumRestorePrice,attr"`
+	PremiumTransferPrice     Money  `xml:"PremiumTransferPrice,attr"`
+	IcannFee                 Money  `xml:"IcannFee,attr"`
 }
 
 type TLDListResult struct {
@@ -76,27 +87,48 @@ type TLDListResult struct {
 }
 
 type DomainCreateResult struct {
-	Domain            string  `xml:"Domain,attr"`
-	Registered        bool    `xml:"Registered,attr"`
-	ChargedAmount     float64 `xml:"ChargedAmount,attr"`
-	DomainID          int     `xml:"DomainID,attr"`
-	OrderID           int     `xml:"OrderID,attr"`
-	TransactionID     int     `xml:"TransactionID,attr"`
-	WhoisguardEnable  bool    `xml:"WhoisguardEnable,attr"`
-	NonRealTimeDomain bool    `xml:"NonRealTimeDomain,attr"`
+	Domain            string `xml:"Domain,attr"`
+	Registered        bool   `xml:"Registered,attr"`
+	ChargedAmount     Money  `xml:"ChargedAmount,attr"`
+	DomainID          int    `xml:"DomainID,attr"`
+	OrderID           int    `xml:"OrderID,attr"`
+	TransactionID     int    `xml:"TransactionID,attr"`
+	WhoisguardEnable  bool   `xml:"WhoisguardEnable,attr"`
+	NonRealTimeDomain bool   `xml:"NonRealTimeDomain,attr"`
 }
 
 type DomainRenewResult struct {
-	DomainID      int     `xml:"DomainID,attr"`
-	Name          string  `xml:"DomainName,attr"`
-	Renewed       bool    `xml:"Renew,attr"`
-	ChargedAmount float64 `xml:"ChargedAmount,attr"`
-	OrderID       int     `xml:"OrderID,attr"`
-	TransactionID int     `xml:"TransactionID,attr"`
-	ExpireDate    string  `xml:"DomainDetails>ExpiredDate"`
+	DomainID      int    `xml:"DomainID,attr"`
+	Name          string `xml:"DomainName,attr"`
+	Renewed       bool   `xml:"Renew,attr"`
+	ChargedAmount Money  `xml:"ChargedAmount,attr"`
+	OrderID       int    `xml:"OrderID,attr"`
+	TransactionID int    `xml:"TransactionID,attr"`
+	ExpireDate    Date   `xml:"DomainDetails>ExpiredDate"`
+}
+
+type DomainReactivateResult struct {
+	Name          string `xml:"Domain,attr"`
+	IsSuccess     bool   `xml:"IsSuccess,attr"`
+	ChargedAmount Money  `xml:"ChargedAmount,attr"`
+	OrderID       int    `xml:"OrderID,attr"`
+	TransactionID int    `xml:"TransactionID,attr"`
+}
+
+type DomainReactivateOption struct {
+	PromotionCode string
+	// YearsToAdd renews the domain for more than the default year.
+	YearsToAdd int
+	// IsPremiumDomain and PremiumPrice must be set to reactivate a premium
+	// domain, PremiumPrice being its reactivation price.
+	IsPremiumDomain bool
+	PremiumPrice    Money
 }
 
 type DomainCreateOption struct {
+	// Contacts, if set, are registered instead of the client's Registrant.
+	Contacts *Registrant
+
 	AddFreeWhoisguard      bool
 	WGEnabled              bool
 	Nameservers            []string
@@ -122,6 +154,10 @@ type DomainCreateOption struct {
 	ORGUKLegalType         string
 	ORGUKCompanyID         string
 	ORGUKRegisteredfor     string
+
+	// IdnCode is the language of an internationalized domain name, such as
+	// "GER" or "SPA", required to register one.
+	IdnCode string
 }
 
 type DomainGetContactsResult struct {
@@ -131,27 +167,170 @@ type DomainGetContactsResult struct {
 	Registrant
 }
 
-func (client *Client) DomainsGetList(page int, pageSize int) ([]DomainGetListResult, *Paging, error) {
-	if pageSize > 100 {
-		// Maximum page size supported by the Namecheap API
-		pageSize = 100
+type DomainSetContactsResult struct {
+	Domain    string `xml:"Domain,attr"`
+	IsSuccess bool   `xml:"IsSuccess,attr"`
+}
+
+// TLDAttributes are the extended attributes some TLDs require along with the
+// contacts, such as .us, .eu, .ca and .uk. Only the fields that are set are
+// sent. As for Registrant, all fields must remain strings.
+type TLDAttributes struct {
+	RegistrantNexus        string
+	RegistrantNexusCountry string
+	RegistrantPurpose      string
+	EUAgreeWhoisPolicy     string
+	EUAgreeDeletePolicy    string
+	EUAdrLang              string
+	NUOrgNo                string
+	NUvatNo                string
+	CIRALegalType          string
+	CIRAWhoisDisplay       string
+	CIRAAgreementVersion   string
+	CIRAAgreementValue     string
+	CIRALanguage           string
+	COUKLegalType          string
+	COUKCompanyID          string
+	COUKRegisteredfor      string
+	MEUKLegalType          string
+	MEUKCompanyID          string
+	MEUKRegisteredfor      string
+	ORGUKLegalType         string
+	ORGUKCompanyID         string
+	ORGUKRegisteredfor     string
+}
+
+// addValues adds the fields of attrs that are set to u.
+func (attrs *TLDAttributes) addValues(u url.Values) {
+	stringFields(*attrs, func(name, value string) error {
+		if value != "" {
+			u.Set(name, value)
+		}
+		return nil
+	})
+}
+
+// tldAttributes returns the TLD extended attributes of opt.
+func (opt *DomainCreateOption) tldAttributes() TLDAttributes {
+	return TLDAttributes{
+		RegistrantNexus:        opt.RegistrantNexus,
+		RegistrantNexusCountry: opt.RegistrantNexusCountry,
+		RegistrantPurpose:      opt.RegistrantPurpose,
+		EUAgreeWhoisPolicy:     opt.EUAgreeWhoisPolicy,
+		EUAgreeDeletePolicy:    opt.EUAgreeDeletePolicy,
+		EUAdrLang:              opt.EUAdrLang,
+		NUOrgNo:                opt.NUOrgNo,
+		NUvatNo:                opt.NUvatNo,
+		CIRALegalType:          opt.CIRALegalType,
+		CIRAWhoisDisplay:       opt.CIRAWhoisDisplay,
+		CIRAAgreementVersion:   opt.CIRAAgreementVersion,
+		CIRAAgreementValue:     opt.CIRAAgreementValue,
+		CIRALanguage:           opt.CIRALanguage,
+		COUKLegalType:          opt.COUKLegalType,
+		COUKCompanyID:          opt.COUKCompanyID,
+		COUKRegisteredfor:      opt.COUKRegisteredfor,
+		MEUKLegalType:          opt.MEUKLegalType,
+		MEUKCompanyID:          opt.MEUKCompanyID,
+		MEUKRegisteredfor:      opt.MEUKRegisteredfor,
+		ORGUKLegalType:         opt.ORGUKLegalType,
+		ORGUKCompanyID:         opt.ORGUKCompanyID,
+		ORGUKRegisteredfor:     opt.ORGUKRegisteredfor,
 	}
+}
+
+// DomainListType selects which domains 'domains.getList' returns.
+type DomainListType string
+
+const (
+	DomainListAll      DomainListType = "ALL"
+	DomainListExpiring DomainListType = "EXPIRING"
+	DomainListExpired  DomainListType = "EXPIRED"
+)
+
+// DomainSortBy is the order 'domains.getList' returns domains in.
+type DomainSortBy string
+
+const (
+	SortByName           DomainSortBy = "NAME"
+	SortByNameDesc       DomainSortBy = "NAME_DESC"
+	SortByExpireDate     DomainSortBy = "EXPIREDATE"
+	SortByExpireDateDesc DomainSortBy = "EXPIREDATE_DESC"
+	SortByCreateDate     DomainSortBy = "CREATEDATE"
+	SortByCreateDateDesc DomainSortBy = "CREATEDATE_DESC"
+)
+
+// DomainsQuery holds the options of 'domains.getList'. Empty fields are left
+// to the API's defaults: all domains, in no particular order, 20 per page.
+type DomainsQuery struct {
+	ListType   DomainListType
+	SearchTerm string // only domains whose name contains it
+	SortBy     DomainSortBy
+	Page       int
+	PageSize   int // at most 100
+}
+
+func (query DomainsQuery) values() url.Values {
+	params := url.Values{}
+	if query.ListType != "" {
+		params.Set("ListType", string(query.ListType))
+	}
+	if query.SearchTerm != "" {
+		params.Set("SearchTerm", query.SearchTerm)
+	}
+	if query.SortBy != "" {
+		params.Set("SortBy", string(query.SortBy))
+	}
+	if query.Page > 0 {
+		params.Set("Page", strconv.Itoa(query.Page))
+	}
+	if query.PageSize > 0 {
+		params.Set("PageSize", strconv.Itoa(min(query.PageSize, maxPageSize)))
+	}
+	return params
+}
+
+func (client *Client) DomainsGetList(page int, pageSize int) ([]DomainGetListResult, *Paging, error) {
+	return client.DomainsGetListContext(context.Background(), page, pageSize)
+}
+
+// DomainsGetListContext is like DomainsGetList but takes a context.
+func (client *Client) DomainsGetListContext(ctx context.Context, page int, pageSize int) ([]DomainGetListResult, *Paging, error) {
+	return client.DomainsGetListQueryContext(ctx, DomainsQuery{Page: page, PageSize: pageSize})
+}
+
+// DomainsGetListQuery returns a page of the domains matching query.
+func (client *Client) DomainsGetListQuery(query DomainsQuery) ([]DomainGetListResult, *Paging, error) {
+	return client.DomainsGetListQueryContext(context.Background(), query)
+}
+
+// DomainsGetListQueryContext is like DomainsGetListQuery but takes a context.
+func (client *Client) DomainsGetListQueryContext(ctx context.Context, query DomainsQuery) ([]DomainGetListResult, *Paging, error) {
 	requestInfo := &ApiRequest{
 		command: domainsGetList,
 		method:  "POST",
-		params:  url.Values{},
+		params:  query.values(),
 	}
-	requestInfo.params.Set("Page", strconv.Itoa(page))
-	requestInfo.params.Set("PageSize", strconv.Itoa(pageSize))
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		Domains []DomainGetListResult `xml:"DomainGetListResult>Domain"`
+		Paging  *Paging               `xml:"Paging"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, nil, err
 	}
 
-	return resp.Domains, resp.Paging, nil
+	for i := range result.Domains {
+		result.Domains[i].Name = unicodeDomain(result.Domains[i].Name)
+	}
+	return result.Domains, result.Paging, err
 }
 
 func (client *Client) DomainGetInfo(domainName string) (*DomainInfo, error) {
+	return client.DomainGetInfoContext(context.Background(), domainName)
+}
+
+// DomainGetInfoContext is like DomainGetInfo but takes a context.
+func (client *Client) DomainGetInfoContext(ctx context.Context, domainName string) (*DomainInfo, error) {
 	requestInfo := &ApiRequest{
 		command: domainsGetInfo,
 		method:  "POST",
@@ -160,15 +339,26 @@ func (client *Client) DomainGetInfo(domainName string) (*DomainInfo, error) {
 
 	requestInfo.params.Set("DomainName", domainName)
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		DomainInfo *DomainInfo `xml:"DomainGetInfoResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.DomainInfo, nil
+	if result.DomainInfo != nil {
+		result.DomainInfo.Name = unicodeDomain(result.DomainInfo.Name)
+	}
+	return result.DomainInfo, err
 }
 
 func (client *Client) DomainsCheck(domainNames ...string) ([]DomainCheckResult, error) {
+	return client.DomainsCheckContext(context.Background(), domainNames...)
+}
+
+// DomainsCheckContext is like DomainsCheck but takes a context.
+func (client *Client) DomainsCheckContext(ctx context.Context, domainNames ...string) ([]DomainCheckResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsCheck,
 		method:  "POST",
@@ -176,32 +366,58 @@ func (client *Client) DomainsCheck(domainNames ...string) ([]DomainCheckResult,
 	}
 
 	requestInfo.params.Set("DomainList", strings.Join(domainNames, ","))
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		DomainsCheck []DomainCheckResult `xml:"DomainCheckResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.DomainsCheck, nil
+	for i := range result.DomainsCheck {
+		result.DomainsCheck[i].Domain = unicodeDomain(result.DomainsCheck[i].Domain)
+	}
+	return result.DomainsCheck, err
 }
 
 func (client *Client) DomainsTLDList() ([]TLDListResult, error) {
+	return client.DomainsTLDListContext(context.Background())
+}
+
+// DomainsTLDListContext is like DomainsTLDList but takes a context.
+func (client *Client) DomainsTLDListContext(ctx context.Context) ([]TLDListResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsTLDList,
 		method:  "POST",
 		params:  url.Values{},
 	}
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		TLDList []TLDListResult `xml:"Tlds>Tld"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.TLDList, nil
+	return result.TLDList, err
 }
 
 func (client *Client) DomainCreate(domainName string, years int, options ...DomainCreateOption) (*DomainCreateResult, error) {
-	if client.Registrant == nil {
-		return nil, errors.New("Registrant information on client cannot be empty")
+	return client.DomainCreateContext(context.Background(), domainName, years, options...)
+}
+
+// DomainCreateContext is like DomainCreate but takes a context.
+func (client *Client) DomainCreateContext(ctx context.Context, domainName string, years int, options ...DomainCreateOption) (*DomainCreateResult, error) {
+	var contacts *Registrant
+	for _, opt := range options {
+		if opt.Contacts != nil {
+			contacts = opt.Contacts
+		}
+	}
+	contacts, err := client.contacts(contacts)
+	if err != nil {
+		return nil, err
 	}
 
 	requestInfo := &ApiRequest{
@@ -222,53 +438,41 @@ func (client *Client) DomainCreate(domainName string, years int, options ...Doma
 		if len(opt.Nameservers) > 0 {
 			requestInfo.params.Set("Nameservers", strings.Join(opt.Nameservers, ","))
 		}
-		if opt.RegistrantNexus != "" {
-			requestInfo.params.Set("RegistrantNexus", opt.RegistrantNexus)
-		}
-		if opt.RegistrantPurpose != "" {
-			requestInfo.params.Set("RegistrantPurpose", opt.RegistrantPurpose)
-		}
-		if opt.COUKLegalType != "" {
-			requestInfo.params.Set("COUKLegalType", opt.COUKLegalType)
-		}
-		if opt.COUKCompanyID != "" {
-			requestInfo.params.Set("COUKCompanyID", opt.COUKCompanyID)
-		}
-		if opt.COUKRegisteredfor != "" {
-			requestInfo.params.Set("COUKRegisteredfor", opt.COUKRegisteredfor)
-		}
-		if opt.EUAgreeWhoisPolicy != "" {
-			requestInfo.params.Set("EUAgreeWhoisPolicy", opt.EUAgreeWhoisPolicy)
-		}
-		if opt.EUAgreeDeletePolicy != "" {
-			requestInfo.params.Set("EUAgreeDeletePolicy", opt.EUAgreeDeletePolicy)
-		}
-		if opt.EUAdrLang != "" {
-			requestInfo.params.Set("EUAdrLang", opt.EUAdrLang)
-		}
-		if opt.ORGUKCompanyID != "" {
-			requestInfo.params.Set("ORGUKCompanyID", opt.ORGUKCompanyID)
-		}
-		if opt.ORGUKLegalType != "" {
-			requestInfo.params.Set("ORGUKLegalType", opt.ORGUKLegalType)
-		}
-		if opt.ORGUKRegisteredfor != "" {
-			requestInfo.params.Set("ORGUKRegisteredfor", opt.ORGUKRegisteredfor)
+		attrs := opt.tldAttributes()
+		attrs.addValues(requestInfo.params)
+		if opt.IdnCode != "" {
+			requestInfo.params.Set("IdnCode", opt.IdnCode)
 		}
 	}
-	if err := client.Registrant.addValues(requestInfo.params); err != nil {
+	if isIDN(domainName) && requestInfo.params.Get("IdnCode") == "" {
+		return nil, fmt.Errorf("IdnCode is required to register the internationalized domain %q", domainName)
+	}
+	if err := contacts.addValues(requestInfo.params); err != nil {
 		return nil, err
 	}
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		DomainCreate *DomainCreateResult `xml:"DomainCreateResult"`
+	}
+	_, err = client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.DomainCreate, nil
+	if result.DomainCreate != nil {
+		result.DomainCreate.Domain = unicodeDomain(result.DomainCreate.Domain)
+	}
+	return result.DomainCreate, err
 }
 
+// DomainRenew renews a domain, charging the account. Once the domain has
+// expired it fails with ErrDomainExpired, and DomainReactivate must be used.
 func (client *Client) DomainRenew(domainName string, years int) (*DomainRenewResult, error) {
+	return client.DomainRenewContext(context.Background(), domainName, years)
+}
+
+// DomainRenewContext is like DomainRenew but takes a context.
+func (client *Client) DomainRenewContext(ctx context.Context, domainName string, years int) (*DomainRenewResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsRenew,
 		method:  "POST",
@@ -277,15 +481,73 @@ func (client *Client) DomainRenew(domainName string, years int) (*DomainRenewRes
 	requestInfo.params.Set("DomainName", domainName)
 	requestInfo.params.Set("Years", strconv.Itoa(years))
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		DomainRenew *DomainRenewResult `xml:"DomainRenewResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.DomainRenew, nil
+	if result.DomainRenew != nil {
+		result.DomainRenew.Name = unicodeDomain(result.DomainRenew.Name)
+	}
+	return result.DomainRenew, err
+}
+
+// DomainReactivate reactivates an expired domain, charging the account.
+// DomainRenew fails with ErrDomainExpired for such domains:
+//
+//	result, err := client.DomainRenew(domain, 1)
+//	if errors.Is(err, namecheap.ErrDomainExpired) {
+//		reactivated, err := client.DomainReactivate(domain)
+//		...
+//	}
+func (client *Client) DomainReactivate(domainName string, options ...DomainReactivateOption) (*DomainReactivateResult, error) {
+	return client.DomainReactivateContext(context.Background(), domainName, options...)
+}
+
+// DomainReactivateContext is like DomainReactivate but takes a context.
+func (client *Client) DomainReactivateContext(ctx context.Context, domainName string, options ...DomainReactivateOption) (*DomainReactivateResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsReactivate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	for _, opt := range options {
+		if opt.PromotionCode != "" {
+			requestInfo.params.Set("PromotionCode", opt.PromotionCode)
+		}
+		if opt.YearsToAdd > 0 {
+			requestInfo.params.Set("YearsToAdd", strconv.Itoa(opt.YearsToAdd))
+		}
+		if opt.IsPremiumDomain {
+			requestInfo.params.Set("IsPremiumDomain", "true")
+			requestInfo.params.Set("PremiumPrice", opt.PremiumPrice.Amount())
+		}
+	}
+
+	var result struct {
+		DomainReactivate *DomainReactivateResult `xml:"DomainReactivateResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainReactivate != nil {
+		result.DomainReactivate.Name = unicodeDomain(result.DomainReactivate.Name)
+	}
+	return result.DomainReactivate, err
 }
 
 func (client *Client) DomainGetContacts(domainName string) (*DomainGetContactsResult, error) {
+	return client.DomainGetContactsContext(context.Background(), domainName)
+}
+
+// DomainGetContactsContext is like DomainGetContacts but takes a context.
+func (client *Client) DomainGetContactsContext(ctx context.Context, domainName string) (*DomainGetContactsResult, error) {
 	requestInfo := &ApiRequest{
 		command: domainsGetContacts,
 		method:  "POST",
@@ -293,10 +555,137 @@ func (client *Client) DomainGetContacts(domainName string) (*DomainGetContactsRe
 	}
 	requestInfo.params.Set("DomainName", domainName)
 
-	resp, err := client.do(requestInfo)
+	var result struct {
+		DomainContacts *DomainGetContactsResult `xml:"DomainContactsResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainContacts != nil {
+		result.DomainContacts.Name = unicodeDomain(result.DomainContacts.Name)
+	}
+	return result.DomainContacts, err
+}
+
+// DomainSetContacts replaces the contacts of a domain with contacts, or
+// with the client's Registrant if contacts is nil. The contacts are checked
+// as for DomainCreate. Some TLDs also require extended attributes.
+func (client *Client) DomainSetContacts(domainName string, contacts *Registrant, attributes ...TLDAttributes) (*DomainSetContactsResult, error) {
+	return client.DomainSetContactsContext(context.Background(), domainName, contacts, attributes...)
+}
+
+// DomainSetContactsContext is like DomainSetContacts but takes a context.
+func (client *Client) DomainSetContactsContext(ctx context.Context, domainName string, contacts *Registrant, attributes ...TLDAttributes) (*DomainSetContactsResult, error) {
+	contacts, err := client.contacts(contacts)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp.DomainContacts, nil
+	requestInfo := &ApiRequest{
+		command: domainsSetContacts,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	if err := contacts.addValues(requestInfo.params); err != nil {
+		return nil, err
+	}
+	for _, attrs := range attributes {
+		attrs.addValues(requestInfo.params)
+	}
+
+	var result struct {
+		DomainSetContacts *DomainSetContactsResult `xml:"DomainSetContactResult"`
+	}
+	_, err = client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainSetContacts != nil {
+		result.DomainSetContacts.Domain = unicodeDomain(result.DomainSetContacts.Domain)
+	}
+	return result.DomainSetContacts, err
+}
+
+type DomainGetRegistrarLockResult struct {
+	Domain   string `xml:"Domain,attr"`
+	IsLocked bool   `xml:"RegistrarLockStatus,attr"`
+}
+
+type DomainSetRegistrarLockResult struct {
+	Domain    string `xml:"Domain,attr"`
+	IsSuccess bool   `xml:"IsSuccess,attr"`
+}
+
+// LockAction is the change DomainSetRegistrarLock makes to the registrar
+// lock, which prevents a domain from being transferred away.
+type LockAction string
+
+const (
+	LockActionLock   LockAction = "LOCK"
+	LockActionUnlock LockAction = "UNLOCK"
+)
+
+func (client *Client) DomainGetRegistrarLock(domainName string) (*DomainGetRegistrarLockResult, error) {
+	return client.DomainGetRegistrarLockContext(context.Background(), domainName)
+}
+
+// DomainGetRegistrarLockContext is like DomainGetRegistrarLock but takes a context.
+func (client *Client) DomainGetRegistrarLockContext(ctx context.Context, domainName string) (*DomainGetRegistrarLockResult, error) {
+	requestInfo := &ApiRequest{
+		command: domainsGetRegistrarLock,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+
+	var result struct {
+		DomainGetRegistrarLock *DomainGetRegistrarLockResult `xml:"DomainGetRegistrarLockResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainGetRegistrarLock != nil {
+		result.DomainGetRegistrarLock.Domain = unicodeDomain(result.DomainGetRegistrarLock.Domain)
+	}
+	return result.DomainGetRegistrarLock, err
+}
+
+func (client *Client) DomainSetRegistrarLock(domainName string, action LockAction) (*DomainSetRegistrarLockResult, error) {
+	return client.DomainSetRegistrarLockContext(context.Background(), domainName, action)
+}
+
+// DomainSetRegistrarLockContext is like DomainSetRegistrarLock but takes a context.
+func (client *Client) DomainSetRegistrarLockContext(ctx context.Context, domainName string, action LockAction) (*DomainSetRegistrarLockResult, error) {
+	switch action {
+	case LockActionLock, LockActionUnlock:
+	default:
+		return nil, fmt.Errorf("unknown lock action %q", action)
+	}
+
+	requestInfo := &ApiRequest{
+		command: domainsSetRegistrarLock,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	requestInfo.params.Set("LockAction", string(action))
+
+	var result struct {
+		DomainSetRegistrarLock *DomainSetRegistrarLockResult `xml:"DomainSetRegistrarLockResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainSetRegistrarLock != nil {
+		result.DomainSetRegistrarLock.Domain = unicodeDomain(result.DomainSetRegistrarLock.Domain)
+	}
+	return result.DomainSetRegistrarLock, err
 }
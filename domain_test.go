@@ -1,10 +1,13 @@
 package namecheap
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -64,8 +67,8 @@ func TestDomainsGetList(t *testing.T) {
 		ID:         57579,
 		Name:       "example.com",
 		User:       "anUser",
-		Created:    "11/04/2014",
-		Expires:    "11/04/2015",
+		Created:    mustDate("11/04/2014"),
+		Expires:    mustDate("11/04/2015"),
 		IsExpired:  false,
 		IsLocked:   false,
 		IsPremium:  false,
@@ -136,8 +139,8 @@ func TestDomainGetInfo(t *testing.T) {
 		ID:        57582,
 		Name:      "example.com",
 		Owner:     "anUser",
-		Created:   "11/04/2014",
-		Expires:   "11/04/2015",
+		Created:   mustDate("11/04/2014"),
+		Expires:   mustDate("11/04/2015"),
 		IsExpired: false,
 		IsLocked:  false,
 		IsOwner:   true,
@@ -157,7 +160,7 @@ func TestDomainGetInfo(t *testing.T) {
 		Whoisguard: Whoisguard{
 			Enabled:     "True",
 			ID:          53536,
-			ExpiredDate: "11/04/2015",
+			ExpiredDate: mustDate("11/04/2015"),
 		},
 	}
 
@@ -249,6 +252,9 @@ func TestDomainCreate(t *testing.T) {
 		correctParams.Set("AddFreeWhoisguard", "yes")
 		correctParams.Set("WGEnabled", "yes")
 		correctParams.Set("Nameservers", "ns1.test.com,ns2.test.com")
+		correctParams.Set("RegistrantNexus", "C31")
+		correctParams.Set("RegistrantNexusCountry", "GB")
+		correctParams.Set("RegistrantPurpose", "P1")
 		fillInfo("AuxBilling")
 		fillInfo("Tech")
 		fillInfo("Admin")
@@ -272,6 +278,9 @@ func TestDomainCreate(t *testing.T) {
 			"ns1.test.com",
 			"ns2.test.com",
 		},
+		RegistrantNexus:        "C31",
+		RegistrantNexusCountry: "GB",
+		RegistrantPurpose:      "P1",
 	})
 	if err != nil {
 		t.Fatalf("DomainCreate returned error: %v", nil)
@@ -279,7 +288,7 @@ func TestDomainCreate(t *testing.T) {
 
 	// DomainCreateResult we expect, given the respXML above
 	want := &DomainCreateResult{
-		"domain1.com", true, 20.36, 9007, 196074, 380716, true, false,
+		"domain1.com", true, mustMoney("20.36"), 9007, 196074, 380716, true, false,
 	}
 
 	if !reflect.DeepEqual(result, want) {
@@ -328,10 +337,10 @@ func TestDomainsRenew(t *testing.T) {
 		DomainID:      151378,
 		Name:          "domain1.com",
 		Renewed:       true,
-		ChargedAmount: 650,
+		ChargedAmount: mustMoney("650"),
 		TransactionID: 119569,
 		OrderID:       109116,
-		ExpireDate:    "4/30/2021 11:31:13 AM",
+		ExpireDate:    mustDate("4/30/2021 11:31:13 AM"),
 	}
 	if !reflect.DeepEqual(result, want) {
 		t.Errorf("DomainRenew returned %+v, want %+v", result, want)
@@ -565,3 +574,252 @@ func TestDomainsGetContacts(t *testing.T) {
 		t.Errorf("DomainRenew returned %+v, want %+v", result, want)
 	}
 }
+
+func TestDomainsGetListQuery(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.getList")
+		correctParams.Set("ListType", "EXPIRING")
+		correctParams.Set("SearchTerm", "shop")
+		correctParams.Set("SortBy", "EXPIREDATE_DESC")
+		correctParams.Set("Page", "2")
+		correctParams.Set("PageSize", "100")
+		testBody(t, r, correctParams)
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.getList"><DomainGetListResult>
+			<Domain ID="1" Name="myshop.com" />
+		</DomainGetListResult></CommandResponse></ApiResponse>`)
+	})
+
+	domains, _, err := client.DomainsGetListQuery(DomainsQuery{
+		ListType:   DomainListExpiring,
+		SearchTerm: "shop",
+		SortBy:     SortByExpireDateDesc,
+		Page:       2,
+		PageSize:   500,
+	})
+	if err != nil {
+		t.Fatalf("DomainsGetListQuery returned error: %v", err)
+	}
+	if len(domains) != 1 || domains[0].Name != "myshop.com" {
+		t.Errorf("DomainsGetListQuery returned %+v", domains)
+	}
+}
+
+func TestDomainCreateConcurrentContacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		domain := r.FormValue("DomainName")
+		want := strings.TrimSuffix(domain, ".com")
+		if domain == "default.com" {
+			want = "Default"
+		}
+		for _, contact := range []string{"Registrant", "Tech", "Admin", "AuxBilling"} {
+			if got := r.FormValue(contact + "FirstName"); got != want {
+				t.Errorf("%sFirstName for %s = %v, want %v", contact, domain, got, want)
+			}
+		}
+		fmt.Fprintf(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.create">
+			<DomainCreateResult Domain="%s" Registered="true" />
+		</CommandResponse></ApiResponse>`, domain)
+	})
+
+	if _, err := client.DomainCreate("default.com", 1); err == nil {
+		t.Error("Expected error without contacts")
+	}
+	client.NewRegistrant("Default", "Smith", "Address", "", "City", "CA", "90045", "US", "+1.6613102107", "john@gmail.com")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("customer%d", i)
+			contacts := NewRegistrant(name, "Smith", "Address", "", "City", "CA", "90045", "US", "+1.6613102107", "john@gmail.com")
+			if _, err := client.DomainCreate(name+".com", 1, DomainCreateOption{Contacts: contacts}); err != nil {
+				t.Errorf("DomainCreate returned error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			client.NewRegistrant("Default", "Smith", "Address", "", "City", "CA", "90045", "US", "+1.6613102107", "john@gmail.com")
+			if _, err := client.DomainCreate("default.com", 1); err != nil {
+				t.Errorf("DomainCreate returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDomainCreateOptionTLDAttributes(t *testing.T) {
+	attrs := reflect.TypeOf(TLDAttributes{})
+	opt := reflect.TypeOf(DomainCreateOption{})
+	for i := 0; i < attrs.NumField(); i++ {
+		if _, ok := opt.FieldByName(attrs.Field(i).Name); !ok {
+			t.Errorf("DomainCreateOption has no %s field", attrs.Field(i).Name)
+		}
+	}
+}
+
+func TestDomainSetContacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	contacts := NewRegistrant(
+		"John", "Smith",
+		"8939 S.cross Blvd", "",
+		"CA", "CA", "90045", "US",
+		"+1.6613102107", "john@gmail.com",
+	)
+
+	correctParams := fillDefaultParams(url.Values{})
+	correctParams.Set("Command", "namecheap.domains.setContacts")
+	correctParams.Set("DomainName", "domain1.us")
+	if err := contacts.addValues(correctParams); err != nil {
+		t.Fatal(err)
+	}
+	correctParams.Set("RegistrantNexus", "C11")
+	correctParams.Set("RegistrantPurpose", "P1")
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.setContacts</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.setContacts">
+    <DomainSetContactResult Domain="domain1.us" IsSuccess="true" />
+  </CommandResponse>
+</ApiResponse>`)
+	})
+
+	result, err := client.DomainSetContacts("domain1.us", contacts, TLDAttributes{
+		RegistrantNexus:   "C11",
+		RegistrantPurpose: "P1",
+	})
+	if err != nil {
+		t.Fatalf("DomainSetContacts returned error: %v", err)
+	}
+	want := &DomainSetContactsResult{Domain: "domain1.us", IsSuccess: true}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainSetContacts returned %+v, want %+v", result, want)
+	}
+
+	if _, err := client.DomainSetContacts("domain1.us", nil); err == nil {
+		t.Error("Expected error without contacts")
+	}
+	incomplete := *contacts
+	incomplete.TechEmailAddress = ""
+	if _, err := client.DomainSetContacts("domain1.us", &incomplete); err == nil {
+		t.Error("Expected error for incomplete contacts")
+	}
+}
+
+func TestDomainRegistrarLock(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var correctParams url.Values
+	var respXML string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	correctParams = fillDefaultParams(url.Values{})
+	correctParams.Set("Command", "namecheap.domains.getRegistrarLock")
+	correctParams.Set("DomainName", "domain.com")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.getRegistrarLock">
+		<DomainGetRegistrarLockResult Domain="domain.com" RegistrarLockStatus="true" />
+	</CommandResponse></ApiResponse>`
+	lock, err := client.DomainGetRegistrarLock("domain.com")
+	if err != nil {
+		t.Fatalf("DomainGetRegistrarLock returned error: %v", err)
+	}
+	if want := (&DomainGetRegistrarLockResult{Domain: "domain.com", IsLocked: true}); !reflect.DeepEqual(lock, want) {
+		t.Errorf("DomainGetRegistrarLock returned %+v, want %+v", lock, want)
+	}
+
+	correctParams = fillDefaultParams(url.Values{})
+	correctParams.Set("Command", "namecheap.domains.setRegistrarLock")
+	correctParams.Set("DomainName", "domain.com")
+	correctParams.Set("LockAction", "UNLOCK")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.setRegistrarLock">
+		<DomainSetRegistrarLockResult Domain="domain.com" IsSuccess="true" />
+	</CommandResponse></ApiResponse>`
+	set, err := client.DomainSetRegistrarLock("domain.com", LockActionUnlock)
+	if err != nil {
+		t.Fatalf("DomainSetRegistrarLock returned error: %v", err)
+	}
+	if want := (&DomainSetRegistrarLockResult{Domain: "domain.com", IsSuccess: true}); !reflect.DeepEqual(set, want) {
+		t.Errorf("DomainSetRegistrarLock returned %+v, want %+v", set, want)
+	}
+
+	if _, err := client.DomainSetRegistrarLock("domain.com", "OPEN"); err == nil {
+		t.Error("Expected error for an unknown lock action")
+	}
+}
+
+func TestDomainReactivate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("Command") {
+		case "namecheap.domains.renew":
+			fmt.Fprint(w, `<ApiResponse Status="ERROR"><Errors>
+				<Error Number="2020166">Domain has expired. Please reactivate it.</Error>
+			</Errors></ApiResponse>`)
+		case "namecheap.domains.reactivate":
+			want := fillDefaultParams(url.Values{})
+			want.Set("Command", "namecheap.domains.reactivate")
+			want.Set("DomainName", "domain1.com")
+			want.Set("PromotionCode", "SAVE10")
+			want.Set("YearsToAdd", "2")
+			want.Set("IsPremiumDomain", "true")
+			want.Set("PremiumPrice", "1450.50")
+			if r.PostForm.Encode() != want.Encode() {
+				t.Errorf("Body:\n %v\nwant:\n %v", r.PostForm.Encode(), want.Encode())
+			}
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.reactivate</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.reactivate">
+    <DomainReactivateResult Domain="domain1.com" IsSuccess="true" ChargedAmount="1450.5000" OrderID="23569" TransactionID="25080" />
+  </CommandResponse>
+</ApiResponse>`)
+		}
+	})
+
+	_, err := client.DomainRenew("domain1.com", 1)
+	if !errors.Is(err, ErrDomainExpired) {
+		t.Fatalf("DomainRenew returned %v, want ErrDomainExpired", err)
+	}
+
+	result, err := client.DomainReactivate("domain1.com", DomainReactivateOption{
+		PromotionCode:   "SAVE10",
+		YearsToAdd:      2,
+		IsPremiumDomain: true,
+		PremiumPrice:    mustMoney("1450.50"),
+	})
+	if err != nil {
+		t.Fatalf("DomainReactivate returned error: %v", err)
+	}
+	want := &DomainReactivateResult{
+		Name:          "domain1.com",
+		IsSuccess:     true,
+		ChargedAmount: mustMoney("1450.50"),
+		OrderID:       23569,
+		TransactionID: 25080,
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("DomainReactivate returned %+v, want %+v", result, want)
+	}
+}
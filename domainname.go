@@ -0,0 +1,146 @@
+package namecheap
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidDomainName is returned, wrapped, for names that cannot be split
+// into a second-level domain and a TLD.
+var ErrInvalidDomainName = errors.New("invalid domain name")
+
+// publicSuffixList is the ICANN section of the Public Suffix List
+// (https://publicsuffix.org/list/). Private suffixes such as github.io are
+// left out, as they are not registries Namecheap can register names in.
+// Refresh it with `make publicsuffix`.
+//
+//go:embed public_suffix_list.dat
+var publicSuffixList string
+
+// Kinds of public suffix rules, as flags since a name may carry several.
+const (
+	suffixRule    = 1 << iota // name is a public suffix
+	wildcardRule              // every child of name is a public suffix
+	exceptionRule             // name is not a public suffix despite a wildcard
+)
+
+var (
+	publicSuffixOnce  sync.Once
+	publicSuffixRules map[string]uint8
+)
+
+func loadPublicSuffixes() {
+	publicSuffixRules = make(map[string]uint8, 8192)
+	for _, line := range strings.Split(publicSuffixList, "\n") {
+		rule, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch {
+		case rule == "" || strings.HasPrefix(rule, "//"):
+		case strings.HasPrefix(rule, "!"):
+			publicSuffixRules[strings.ToLower(rule[1:])] |= exceptionRule
+		case strings.HasPrefix(rule, "*."):
+			publicSuffixRules[strings.ToLower(rule[2:])] |= wildcardRule
+		default:
+			publicSuffixRules[strings.ToLower(rule)] |= suffixRule
+		}
+	}
+}
+
+// publicSuffixLen returns how many of the trailing labels form the public
+// suffix of a name, following the algorithm of publicsuffix.org: the longest
+// matching rule wins, exception rules beat all others, and an unknown TLD is
+// a public suffix by itself.
+func publicSuffixLen(labels []string) int {
+	publicSuffixOnce.Do(loadPublicSuffixes)
+
+	n := 1
+	for i := len(labels) - 1; i >= 0; i-- {
+		name := strings.Join(labels[i:], ".")
+		rules := publicSuffixRules[name]
+		if rules&exceptionRule != 0 {
+			return len(labels) - i - 1
+		}
+		if rules&suffixRule != 0 {
+			n = len(labels) - i
+		}
+		if i > 0 && rules&wildcardRule != 0 {
+			n = len(labels) - i + 1
+		}
+	}
+	return n
+}
+
+// DomainName is a domain name split the way Namecheap commands taking SLD
+// and TLD parameters expect, e.g. "example" and "co.uk".
+type DomainName struct {
+	SLD string
+	TLD string
+}
+
+func (name DomainName) String() string {
+	return name.SLD + "." + name.TLD
+}
+
+// ParseDomainName splits a registrable domain name such as "example.co.uk"
+// into its SLD and TLD using the Public Suffix List, so multi-label TLDs are
+// split correctly. Subdomains such as "www.example.com" are rejected, as are
+// bare TLDs. The name is normalized as by ToUnicode, so the SLD and TLD of
+// internationalized names are in Unicode.
+func ParseDomainName(name string) (DomainName, error) {
+	return (*DomainParser)(nil).Parse(name)
+}
+
+// DomainParser is a ParseDomainName restricted to a set of TLDs, typically
+// those Namecheap sells:
+//
+//	tlds, err := client.DomainsTLDList()
+//	...
+//	client.DomainParser = namecheap.NewDomainParser(tlds...)
+type DomainParser struct {
+	tlds map[string]bool
+}
+
+// NewDomainParser returns a parser accepting only names under tlds.
+func NewDomainParser(tlds ...TLDListResult) *DomainParser {
+	parser := &DomainParser{tlds: make(map[string]bool, len(tlds))}
+	for _, tld := range tlds {
+		name := strings.ToLower(strings.Trim(tld.Name, "."))
+		if decoded, err := ToUnicode(name); err == nil {
+			name = decoded
+		}
+		parser.tlds[name] = true
+	}
+	return parser
+}
+
+// Parse is like ParseDomainName, but the TLD is the longest suffix of the
+// name's public suffix the parser knows. A nil parser accepts any TLD.
+func (parser *DomainParser) Parse(name string) (DomainName, error) {
+	labels, err := domainLabels(name)
+	if err != nil {
+		return DomainName{}, err
+	}
+	if len(labels) < 2 {
+		return DomainName{}, fmt.Errorf("%w %q: no TLD", ErrInvalidDomainName, name)
+	}
+
+	n := publicSuffixLen(labels)
+	if parser != nil {
+		for n > 0 && !parser.tlds[strings.Join(labels[len(labels)-n:], ".")] {
+			n--
+		}
+		if n == 0 {
+			return DomainName{}, fmt.Errorf("%w %q: unsupported TLD", ErrInvalidDomainName, name)
+		}
+	}
+	switch sld := len(labels) - n; {
+	case sld == 0:
+		return DomainName{}, fmt.Errorf("%w %q: is a TLD", ErrInvalidDomainName, name)
+	case sld > 1:
+		return DomainName{}, fmt.Errorf("%w %q: is a subdomain of %s",
+			ErrInvalidDomainName, name, strings.Join(labels[sld-1:], "."))
+	}
+	return DomainName{SLD: labels[0], TLD: strings.Join(labels[1:], ".")}, nil
+}
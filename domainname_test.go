@@ -0,0 +1,77 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseDomainName(t *testing.T) {
+	cases := map[string]DomainName{
+		"example.com":    {"example", "com"},
+		"Example.CO.UK.": {"example", "co.uk"},
+		"example.com.au": {"example", "com.au"},
+		"example.uk":     {"example", "uk"},
+		"my-site.org":    {"my-site", "org"},
+		"www.ck":         {"www", "ck"}, // exception to *.ck
+	}
+	for in, want := range cases {
+		got, err := ParseDomainName(in)
+		if err != nil || got != want {
+			t.Errorf("ParseDomainName(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+
+	// github.io is a private suffix, left out of the embedded list, so
+	// example.github.io is a subdomain.
+	for _, in := range []string{"", "com", "example.github.io", "co.uk", "www.example.com", "foo.ck", "example.nom.br", "exa_mple.com", "-example.com", "example..com"} {
+		if got, err := ParseDomainName(in); !errors.Is(err, ErrInvalidDomainName) {
+			t.Errorf("ParseDomainName(%q) = %v, %v, want ErrInvalidDomainName", in, got, err)
+		}
+	}
+}
+
+func TestDomainParser(t *testing.T) {
+	parser := NewDomainParser(TLDListResult{Name: "com"}, TLDListResult{Name: "uk"})
+
+	if got, err := parser.Parse("example.com"); err != nil || got.String() != "example.com" {
+		t.Errorf("Parse(example.com) = %v, %v", got, err)
+	}
+	if got, err := parser.Parse("example.uk"); err != nil || got.TLD != "uk" {
+		t.Errorf("Parse(example.uk) = %v, %v", got, err)
+	}
+	// co.uk is not known to the parser, so example.co.uk is a subdomain of
+	// co.uk rather than a wrong split.
+	if got, err := parser.Parse("example.co.uk"); !errors.Is(err, ErrInvalidDomainName) {
+		t.Errorf("Parse(example.co.uk) = %v, %v, want ErrInvalidDomainName", got, err)
+	}
+	if got, err := parser.Parse("example.net"); !errors.Is(err, ErrInvalidDomainName) {
+		t.Errorf("Parse(example.net) = %v, %v, want ErrInvalidDomainName", got, err)
+	}
+}
+
+func TestDomainsDNSGetHostsByName(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("SLD") != "example" || r.FormValue("TLD") != "co.uk" {
+			t.Errorf("SLD, TLD = %v, %v, want example, co.uk", r.FormValue("SLD"), r.FormValue("TLD"))
+		}
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.dns.getHosts">
+			<DomainDNSGetHostsResult Domain="example.co.uk" IsUsingOurDNS="true" />
+		</CommandResponse></ApiResponse>`)
+	})
+
+	result, err := client.DomainsDNSGetHostsByName("example.co.uk")
+	if err != nil {
+		t.Fatalf("DomainsDNSGetHostsByName returned error: %v", err)
+	}
+	if result.Domain != "example.co.uk" {
+		t.Errorf("DomainsDNSGetHostsByName returned %+v", result)
+	}
+	if _, err := client.DomainsDNSGetHostsByName("www.example.co.uk"); !errors.Is(err, ErrInvalidDomainName) {
+		t.Errorf("Expected ErrInvalidDomainName for a subdomain, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors for the failure classes callers usually need to tell apart.
+// Errors returned by the API are matched against them with errors.Is, e.g.
+//
+//	if errors.Is(err, namecheap.ErrDomainNotFound) { ... }
+var (
+	ErrAuthentication    = errors.New("namecheap: authentication failed")
+	ErrIPNotWhitelisted  = errors.New("namecheap: client IP is not whitelisted")
+	ErrDomainNotFound    = errors.New("namecheap: domain not found")
+	ErrDomainNotOwned    = errors.New("namecheap: domain is not associated with this account")
+	ErrDomainExpired     = errors.New("namecheap: domain has expired and must be reactivated")
+	ErrInsufficientFunds = errors.New("namecheap: insufficient funds")
+	ErrInvalidParameter  = errors.New("namecheap: invalid parameter")
+)
+
+// errorNumbers maps Namecheap error numbers to the sentinel they represent.
+var errorNumbers = map[int]error{
+	1010101: ErrAuthentication, // Parameter APIUser is missing
+	1010102: ErrAuthentication, // Parameter APIKey is missing
+	1011102: ErrAuthentication, // Parameter APIKey is invalid
+	1030408: ErrAuthentication, // Unsupported authentication type
+	1050900: ErrAuthentication, // Unknown error when validating APIUser
+
+	1011105: ErrIPNotWhitelisted, // Parameter ClientIP is invalid
+	1017105: ErrIPNotWhitelisted, // Parameter ClientIP is disabled or locked
+	1011150: ErrIPNotWhitelisted, // Parameter RequestIP is invalid
+	1017150: ErrIPNotWhitelisted, // Parameter RequestIP is disabled or locked
+
+	2019166: ErrDomainNotFound, // Domain not found
+	2016166: ErrDomainNotOwned, // Domain is not associated with your account
+	2020166: ErrDomainExpired,  // Domain has expired
+
+	500000: ErrRateLimited, // Too many requests
+}
+
+// ApiError is the format of the error returned in the api responses.
+type ApiError struct {
+	Number  int    `xml:"Number,attr"`
+	Message string `xml:",innerxml"`
+}
+
+func (err *ApiError) Error() string {
+	return err.Message
+}
+
+// Is reports whether the error number belongs to the class of target,
+// one of the sentinel errors of this package.
+func (err *ApiError) Is(target error) bool {
+	if sentinel, ok := errorNumbers[err.Number]; ok {
+		return sentinel == target
+	}
+
+	// Orders failing for lack of funds share their error number, e.g.
+	// 2528166 (Order creation failed), with every other failed order, so
+	// only the message tells them apart.
+	if target == ErrInsufficientFunds {
+		return strings.Contains(strings.ToLower(err.Message), "insufficient funds")
+	}
+
+	// Error numbers starting with 2010, 2011 and 2015 report missing,
+	// invalid and failed validation of request parameters.
+	switch err.Number / 1000 {
+	case 2010, 2011, 2015:
+		return target == ErrInvalidParameter
+	}
+	return false
+}
+
+// ApiErrors holds multiple ApiError's but implements the error interface.
+// errors.Is and errors.As look at every ApiError it holds.
+type ApiErrors []ApiError
+
+func (errs ApiErrors) Error() string {
+	errMsg := ""
+	for _, apiError := range errs {
+		errMsg += fmt.Sprintf("Error %d: %s\n", apiError.Number, apiError.Message)
+	}
+	return errMsg
+}
+
+// Unwrap returns every ApiError as an error.
+func (errs ApiErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i := range errs {
+		unwrapped[i] = &errs[i]
+	}
+	return unwrapped
+}
+
+// HTTPStatusError is returned when the API answers with a status code other
+// than 200 OK.
+type HTTPStatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (err *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code from api: %d - %s", err.StatusCode, err.Body)
+}
+
+// Is reports a 429 Too Many Requests status as ErrRateLimited.
+func (err *HTTPStatusError) Is(target error) bool {
+	return target == ErrRateLimited && err.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,82 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestApiErrorsIs(t *testing.T) {
+	errs := ApiErrors{
+		{Number: 2011170, Message: "Validation error from PromotionCode"},
+		{Number: 2019166, Message: "Domain not found"},
+	}
+
+	if !errors.Is(errs, ErrDomainNotFound) {
+		t.Error("Expected errors.Is to match ErrDomainNotFound")
+	}
+	if !errors.Is(errs, ErrInvalidParameter) {
+		t.Error("Expected errors.Is to match ErrInvalidParameter")
+	}
+	if errors.Is(errs, ErrAuthentication) {
+		t.Error("Did not expect errors.Is to match ErrAuthentication")
+	}
+
+	var apiErr *ApiError
+	if !errors.As(errs, &apiErr) || apiErr.Number != 2011170 {
+		t.Errorf("Expected errors.As to return the first ApiError, got %v", apiErr)
+	}
+
+	orderErr := &ApiError{Number: 2528166, Message: "Order creation failed"}
+	if errors.Is(orderErr, ErrInsufficientFunds) {
+		t.Error("Did not expect a failed order to match ErrInsufficientFunds")
+	}
+	orderErr.Message = "Order creation failed: Insufficient funds in your account"
+	if !errors.Is(orderErr, ErrInsufficientFunds) {
+		t.Error("Expected a failed order for lack of funds to match ErrInsufficientFunds")
+	}
+	if !errors.Is(&ApiError{Number: 1017105}, ErrIPNotWhitelisted) {
+		t.Error("Expected a locked ClientIP to match ErrIPNotWhitelisted")
+	}
+
+	wrapped := fmt.Errorf("getting info: %w", errs)
+	if !errors.Is(wrapped, ErrDomainNotFound) {
+		t.Error("Expected errors.Is to see through wrapping")
+	}
+}
+
+func TestDoTypedErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	state := "error"
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		switch state {
+		case "429":
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, "slow down")
+		default:
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+				<Errors>
+					<Error Number="1011150">Parameter RequestIP is invalid</Error>
+				</Errors>
+			</ApiResponse>`)
+		}
+	})
+
+	_, err := client.DomainGetInfo("example.com")
+	if !errors.Is(err, ErrIPNotWhitelisted) {
+		t.Errorf("Expected ErrIPNotWhitelisted, got %v", err)
+	}
+
+	state = "429"
+	_, err = client.DomainGetInfo("example.com")
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || string(statusErr.Body) != "slow down" {
+		t.Errorf("Expected HTTPStatusError carrying the body, got %v", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected 429 to match ErrRateLimited, got %v", err)
+	}
+}
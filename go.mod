@@ -0,0 +1,7 @@
+module github.com/jawr/go-namecheap
+
+go 1.23.0
+
+require golang.org/x/net v0.42.0
+
+require golang.org/x/text v0.27.0 // indirect
@@ -0,0 +1,119 @@
+package namecheap
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// acePrefix marks a label holding an internationalized label in punycode.
+const acePrefix = "xn--"
+
+// idnaProfile converts domain names as UTS #46 does for lookups, without the
+// transitional mapping of IDNA 2003 so that e.g. "ß" is kept, and checks the
+// labels and length are valid for DNS.
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+	idna.VerifyDNSLength(true),
+)
+
+// ToASCII converts a domain name to the ASCII form sent to the API, encoding
+// internationalized labels in punycode, e.g. "bücher.de" to
+// "xn--bcher-kva.de". The name is first normalized and mapped as UTS #46
+// specifies, so it is lower-cased, put in Unicode normalization form C,
+// fullwidth characters are narrowed and ideographic full stops are treated
+// as dots. A trailing dot is dropped.
+//
+// Labels that are not valid host name labels, such as ones containing
+// spaces or punctuation, or starting or ending with a hyphen, are rejected
+// with an error wrapping ErrInvalidDomainName.
+func ToASCII(name string) (string, error) {
+	ascii, _, err := convertDomain(name)
+	return ascii, err
+}
+
+// ToUnicode converts a domain name returned by the API to Unicode, decoding
+// labels in punycode, e.g. "xn--bcher-kva.de" to "bücher.de". Names that
+// are not valid are returned unchanged along with the error.
+func ToUnicode(name string) (string, error) {
+	_, unicode, err := convertDomain(name)
+	if err != nil {
+		return name, err
+	}
+	return unicode, nil
+}
+
+// domainParams are the parameters holding domain names, which are sent in
+// ASCII. DomainList holds several, separated by commas.
+var domainParams = []string{"DomainName", "DomainList", "SLD", "TLD"}
+
+// encodeDomainParams converts the domain names in params with ToASCII.
+func encodeDomainParams(params url.Values) error {
+	for _, key := range domainParams {
+		for i, value := range params[key] {
+			if value == "" {
+				continue
+			}
+			names := strings.Split(value, ",")
+			for j, name := range names {
+				ascii, err := ToASCII(name)
+				if err != nil {
+					return fmt.Errorf("%s: %w", key, err)
+				}
+				names[j] = ascii
+			}
+			params[key][i] = strings.Join(names, ",")
+		}
+	}
+	return nil
+}
+
+// isIDN reports whether name is an internationalized domain name, in
+// Unicode or in punycode.
+func isIDN(name string) bool {
+	ascii, err := ToASCII(name)
+	return err == nil && (strings.HasPrefix(ascii, acePrefix) || strings.Contains(ascii, "."+acePrefix))
+}
+
+// unicodeDomain is ToUnicode for names in results, which are left as
+// returned by the API if they cannot be decoded.
+func unicodeDomain(name string) string {
+	if !strings.Contains(name, acePrefix) {
+		return name
+	}
+	decoded, _ := ToUnicode(name)
+	return decoded
+}
+
+// domainLabels returns the labels of name in normalized Unicode.
+func domainLabels(name string) ([]string, error) {
+	_, unicode, err := convertDomain(name)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(unicode, "."), nil
+}
+
+// convertDomain returns name in ASCII and in Unicode, both normalized with
+// idnaProfile and without a trailing dot.
+func convertDomain(name string) (ascii, unicode string, err error) {
+	s := strings.TrimSpace(name)
+	if s == "" {
+		return "", "", fmt.Errorf("%w %q: empty", ErrInvalidDomainName, name)
+	}
+	if ascii, err = idnaProfile.ToASCII(s); err == nil {
+		ascii = strings.TrimSuffix(ascii, ".")
+		unicode, err = idnaProfile.ToUnicode(ascii)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("%w %q: %v", ErrInvalidDomainName, name, err)
+	}
+	if ascii == "" {
+		return "", "", fmt.Errorf("%w %q: empty", ErrInvalidDomainName, name)
+	}
+	return ascii, unicode, nil
+}
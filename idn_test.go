@@ -0,0 +1,96 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestToASCII(t *testing.T) {
+	cases := map[string]string{
+		"example.com":      "example.com",
+		"Bücher.DE.":       "xn--bcher-kva.de",
+		"münchen。de":       "xn--mnchen-3ya.de",
+		"xn--bcher-kva.de": "xn--bcher-kva.de",
+		"例子.公司.cn":         "xn--fsqu00a.xn--55qx5d.cn",
+		"bücher.xn--p1ai":  "xn--bcher-kva.xn--p1ai",
+		"bu\u0308cher.de":  "xn--bcher-kva.de",
+		"ＢＵＣＨＥＲ.de":        "bucher.de",
+		"straße.de":        "xn--strae-oqa.de",
+		"3年b組金八先生.jp":      "xn--3b-ww4c5e180e575a65lsy2b.jp",
+	}
+	for in, want := range cases {
+		if got, err := ToASCII(in); err != nil || got != want {
+			t.Errorf("ToASCII(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+
+	for _, in := range []string{"", "bü cher.de", "bücher!.de", "-bücher.de", "ab--cd.com", "xn--zz.de", "xn--abc.de", "́a.de", "a..de", "a\u200db.de"} {
+		if got, err := ToASCII(in); !errors.Is(err, ErrInvalidDomainName) {
+			t.Errorf("ToASCII(%q) = %q, %v, want ErrInvalidDomainName", in, got, err)
+		}
+	}
+}
+
+func TestToUnicode(t *testing.T) {
+	if got, err := ToUnicode("xn--bcher-kva.xn--p1ai"); err != nil || got != "bücher.рф" {
+		t.Errorf("ToUnicode = %q, %v, want bücher.рф", got, err)
+	}
+	if name, err := ParseDomainName("xn--fsqu00a.xn--55qx5d.cn"); err != nil || name.SLD != "例子" || name.TLD != "公司.cn" {
+		t.Errorf("ParseDomainName = %+v, %v, want 例子 and 公司.cn", name, err)
+	}
+}
+
+func TestDomainsCheckIDN(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.FormValue("DomainList"); got != "xn--bcher-kva.de,example.com" {
+			t.Errorf("DomainList = %v, want the names in punycode", got)
+		}
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.check">
+			<DomainCheckResult Domain="xn--bcher-kva.de" Available="true" />
+			<DomainCheckResult Domain="example.com" Available="false" />
+		</CommandResponse></ApiResponse>`)
+	})
+
+	results, err := client.DomainsCheck("bücher.de", "example.com")
+	if err != nil {
+		t.Fatalf("DomainsCheck returned error: %v", err)
+	}
+	if results[0].Domain != "bücher.de" || results[1].Domain != "example.com" {
+		t.Errorf("DomainsCheck returned %+v", results)
+	}
+
+	if _, err := client.DomainsCheck("bü cher.de"); !errors.Is(err, ErrInvalidDomainName) {
+		t.Errorf("Expected ErrInvalidDomainName, got %v", err)
+	}
+}
+
+func TestDomainCreateIDN(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("DomainName") != "xn--bcher-kva.de" || r.FormValue("IdnCode") != "GER" {
+			t.Errorf("DomainName, IdnCode = %v, %v", r.FormValue("DomainName"), r.FormValue("IdnCode"))
+		}
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.create">
+			<DomainCreateResult Domain="xn--bcher-kva.de" Registered="true" />
+		</CommandResponse></ApiResponse>`)
+	})
+	client.NewRegistrant("John", "Smith", "Address", "", "City", "CA", "90045", "US", "+1.6613102107", "john@gmail.com")
+
+	if _, err := client.DomainCreate("bücher.de", 1); err == nil {
+		t.Error("Expected error without IdnCode")
+	}
+	result, err := client.DomainCreate("bücher.de", 1, DomainCreateOption{IdnCode: "GER"})
+	if err != nil {
+		t.Fatalf("DomainCreate returned error: %v", err)
+	}
+	if result.Domain != "bücher.de" {
+		t.Errorf("DomainCreate returned %+v", result)
+	}
+}
@@ -0,0 +1,116 @@
+// Package instrumentation records OpenTelemetry spans and Prometheus metrics
+// for the calls made by a namecheap.Client.
+//
+// It lives in its own module so that users of namecheap who do not import
+// it do not depend on OpenTelemetry or Prometheus.
+//
+//	metrics, err := instrumentation.NewMetrics(prometheus.DefaultRegisterer)
+//	if err != nil { ... }
+//	client := namecheap.NewClient(apiUser, apiToken, userName,
+//		namecheap.WithMiddleware(
+//			instrumentation.TracingMiddleware(otel.GetTracerProvider()),
+//			metrics.Middleware(),
+//		),
+//	)
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	namecheap "github.com/jawr/go-namecheap"
+)
+
+// Error classes reported by ErrorClass.
+const (
+	ClassAuthentication    = "authentication"
+	ClassIPNotWhitelisted  = "ip_not_whitelisted"
+	ClassDomainNotFound    = "domain_not_found"
+	ClassDomainNotOwned    = "domain_not_owned"
+	ClassDomainExpired     = "domain_expired"
+	ClassInsufficientFunds = "insufficient_funds"
+	ClassInvalidParameter  = "invalid_parameter"
+	ClassRateLimited       = "rate_limited"
+	ClassCanceled          = "canceled"
+	ClassHTTPStatus        = "http_status"
+	ClassTransport         = "transport"
+	ClassAPI               = "api"
+	ClassOther             = "other"
+)
+
+var sentinelClasses = []struct {
+	err   error
+	class string
+}{
+	{namecheap.ErrRateLimited, ClassRateLimited},
+	{namecheap.ErrAuthentication, ClassAuthentication},
+	{namecheap.ErrIPNotWhitelisted, ClassIPNotWhitelisted},
+	{namecheap.ErrDomainNotFound, ClassDomainNotFound},
+	{namecheap.ErrDomainNotOwned, ClassDomainNotOwned},
+	{namecheap.ErrDomainExpired, ClassDomainExpired},
+	{namecheap.ErrInsufficientFunds, ClassInsufficientFunds},
+	{namecheap.ErrInvalidParameter, ClassInvalidParameter},
+}
+
+// ErrorClass returns a low-cardinality label describing err, suitable for
+// metrics. It returns an empty string for a nil error.
+func ErrorClass(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, sc := range sentinelClasses {
+		if errors.Is(err, sc.err) {
+			return sc.class
+		}
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ClassCanceled
+	}
+
+	var statusErr *namecheap.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return ClassHTTPStatus
+	}
+	var apiErrs namecheap.ApiErrors
+	if errors.As(err, &apiErrs) {
+		return ClassAPI
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ClassTransport
+	}
+	return ClassOther
+}
+
+// errorNumbers returns the Namecheap error numbers wrapped in err.
+func errorNumbers(err error) []int {
+	var apiErrs namecheap.ApiErrors
+	if !errors.As(err, &apiErrs) {
+		return nil
+	}
+	numbers := make([]int, len(apiErrs))
+	for i, apiErr := range apiErrs {
+		numbers[i] = apiErr.Number
+	}
+	return numbers
+}
+
+// domainOf returns the domain a call is about, if any.
+func domainOf(exchange *namecheap.Exchange) string {
+	if name := exchange.Params.Get("DomainName"); name != "" {
+		return name
+	}
+	if sld, tld := exchange.Params.Get("SLD"), exchange.Params.Get("TLD"); sld != "" && tld != "" {
+		return sld + "." + tld
+	}
+	return ""
+}
+
+// status returns the Status attribute of the response, if one was decoded.
+func status(exchange *namecheap.Exchange) string {
+	if exchange.Response == nil {
+		return ""
+	}
+	return exchange.Response.Status
+}
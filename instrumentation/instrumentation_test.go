@@ -0,0 +1,103 @@
+package instrumentation
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	namecheap "github.com/jawr/go-namecheap"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const notFoundXML = `<?xml version="1.0" encoding="utf-8"?><ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+	<Errors>
+		<Error Number="2019166">Domain not found</Error>
+	</Errors>
+</ApiResponse>`
+
+func TestInstrumentation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, notFoundXML)
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	if err != nil {
+		t.Fatalf("NewMetrics returned error: %v", err)
+	}
+
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser",
+		namecheap.WithBaseURL(server.URL+"/"),
+		namecheap.WithMiddleware(TracingMiddleware(provider), metrics.Middleware()),
+	)
+	if _, err := client.DomainGetInfo("example.com"); err == nil {
+		t.Fatal("Expected error from DomainGetInfo")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "namecheap.domains.getInfo" || span.Status().Code != codes.Error {
+		t.Errorf("Unexpected span %q with status %v", span.Name(), span.Status())
+	}
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if attrs["namecheap.domain"].AsString() != "example.com" {
+		t.Errorf("namecheap.domain = %v", attrs["namecheap.domain"].Emit())
+	}
+	if attrs["namecheap.status"].AsString() != "ERROR" {
+		t.Errorf("namecheap.status = %v", attrs["namecheap.status"].Emit())
+	}
+	if got := attrs["namecheap.error_numbers"].AsInt64Slice(); len(got) != 1 || got[0] != 2019166 {
+		t.Errorf("namecheap.error_numbers = %v", got)
+	}
+
+	if got := testutil.ToFloat64(metrics.calls.WithLabelValues("namecheap.domains.getInfo", "error")); got != 1 {
+		t.Errorf("calls{result=error} = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.errors.WithLabelValues("namecheap.domains.getInfo", ClassDomainNotFound)); got != 1 {
+		t.Errorf("errors{class=domain_not_found} = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(metrics.duration); got != 1 {
+		t.Errorf("Expected 1 duration series, got %d", got)
+	}
+}
+
+func TestRateLimitRejections(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	if err != nil {
+		t.Fatalf("NewMetrics returned error: %v", err)
+	}
+
+	limiter := namecheap.NewRateLimiter(namecheap.RateWindow{Limit: 0, Period: time.Hour})
+	limiter.FailFast = true
+	client := namecheap.NewClient("anApiUser", "anToken", "anUser",
+		namecheap.WithRateLimiter(limiter),
+		namecheap.WithMiddleware(metrics.Middleware()),
+	)
+	if _, err := client.DomainGetInfo("example.com"); err == nil {
+		t.Fatal("Expected error from DomainGetInfo")
+	}
+
+	if got := testutil.ToFloat64(metrics.rateLimitRejection.WithLabelValues("namecheap.domains.getInfo")); got != 1 {
+		t.Errorf("rate_limit_rejections = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.errors.WithLabelValues("namecheap.domains.getInfo", ClassRateLimited)); got != 1 {
+		t.Errorf("errors{class=rate_limited} = %v, want 1", got)
+	}
+}
@@ -0,0 +1,75 @@
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	namecheap "github.com/jawr/go-namecheap"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the Prometheus collectors updated by its Middleware.
+type Metrics struct {
+	calls              *prometheus.CounterVec
+	duration           *prometheus.HistogramVec
+	errors             *prometheus.CounterVec
+	rateLimitRejection *prometheus.CounterVec
+}
+
+// NewMetrics creates the collectors and registers them with registerer:
+//
+//	namecheap_api_calls_total{command,result}
+//	namecheap_api_call_duration_seconds{command}
+//	namecheap_api_errors_total{command,class}
+//	namecheap_api_rate_limit_rejections_total{command}
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "namecheap_api_calls_total",
+			Help: "Namecheap API calls by command and result (ok or error).",
+		}, []string{"command", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "namecheap_api_call_duration_seconds",
+			Help:    "Duration of Namecheap API calls, including retries and rate limiting.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"command"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "namecheap_api_errors_total",
+			Help: "Failed Namecheap API calls by command and error class.",
+		}, []string{"command", "class"}),
+		rateLimitRejection: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "namecheap_api_rate_limit_rejections_total",
+			Help: "Namecheap API calls rejected by the client or the API for exceeding the rate limit.",
+		}, []string{"command"}),
+	}
+
+	for _, c := range []prometheus.Collector{m.calls, m.duration, m.errors, m.rateLimitRejection} {
+		if err := registerer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Middleware returns the middleware updating m.
+func (m *Metrics) Middleware() namecheap.Middleware {
+	return func(next namecheap.Handler) namecheap.Handler {
+		return func(ctx context.Context, exchange *namecheap.Exchange) error {
+			start := time.Now()
+			err := next(ctx, exchange)
+			m.duration.WithLabelValues(exchange.Command).Observe(time.Since(start).Seconds())
+
+			if err == nil {
+				m.calls.WithLabelValues(exchange.Command, "ok").Inc()
+				return nil
+			}
+			m.calls.WithLabelValues(exchange.Command, "error").Inc()
+			m.errors.WithLabelValues(exchange.Command, ErrorClass(err)).Inc()
+			if errors.Is(err, namecheap.ErrRateLimited) {
+				m.rateLimitRejection.WithLabelValues(exchange.Command).Inc()
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package instrumentation
+
+import (
+	"context"
+
+	namecheap "github.com/jawr/go-namecheap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/jawr/go-namecheap/instrumentation"
+
+// TracingMiddleware records a client span per call, named after the
+// Namecheap command and carrying the domain, response status, number of
+// attempts and error numbers.
+func TracingMiddleware(provider trace.TracerProvider) namecheap.Middleware {
+	tracer := provider.Tracer(instrumentationName)
+
+	return func(next namecheap.Handler) namecheap.Handler {
+		return func(ctx context.Context, exchange *namecheap.Exchange) error {
+			ctx, span := tracer.Start(ctx, exchange.Command,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attribute.String("namecheap.command", exchange.Command)),
+			)
+			defer span.End()
+
+			if domain := domainOf(exchange); domain != "" {
+				span.SetAttributes(attribute.String("namecheap.domain", domain))
+			}
+
+			err := next(ctx, exchange)
+
+			span.SetAttributes(attribute.Int("namecheap.attempts", exchange.Attempts))
+			if exchange.StatusCode != 0 {
+				span.SetAttributes(attribute.Int("http.response.status_code", exchange.StatusCode))
+			}
+			if s := status(exchange); s != "" {
+				span.SetAttributes(attribute.String("namecheap.status", s))
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				span.SetAttributes(attribute.String("error.type", ErrorClass(err)))
+				if numbers := errorNumbers(err); len(numbers) > 0 {
+					span.SetAttributes(attribute.IntSlice("namecheap.error_numbers", numbers))
+				}
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// piiFields are the suffixes of contact parameters and response elements
+// holding personal data, as used by Registrant and AddressGetInfoResult.
+var piiFields = []string{
+	"FirstName", "LastName", "JobTitle", "Organization", "OrganizationName",
+	"Address1", "Address2", "City", "StateProvince", "StateProvinceChoice",
+	"PostalCode", "Zip", "Phone", "PhoneExt", "Fax", "EmailAddress",
+	"ForwardedToEmail", "ForwardedTo", "WhoisGuardEmail",
+}
+
+// accountAttrs are the response attributes naming the account owning a
+// domain, as in DomainGetListResult and DomainGetInfoResult.
+var accountAttrs = []string{"User", "OwnerName"}
+
+var (
+	piiElementPattern = regexp.MustCompile(
+		`(<(?:\w+:)?(?:` + strings.Join(piiFields, "|") + `)(?:\s[^>]*)?>)[^<]*(</)`)
+	piiAttrPattern = regexp.MustCompile(
+		`(\s(?:` + strings.Join(append(piiFields, accountAttrs...), "|") + `)=")[^"]*(")`)
+)
+
+// WithLogger logs every call to logger. See LoggingMiddleware.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return WithMiddleware(LoggingMiddleware(logger))
+}
+
+// LoggingMiddleware logs each call with its command, duration, attempts,
+// status, warnings and Namecheap error numbers: at Info level when it
+// succeeds, at Warn level when the response carries warnings and at Error
+// level when it fails. At Debug level the request parameters and the
+// response body are logged too.
+//
+// Credentials and contact details are never logged: ApiKey, ApiUser and
+// UserName, as well as names, addresses, phone numbers and emails in
+// parameters and responses, are replaced by Redacted.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, exchange *Exchange) error {
+			start := time.Now()
+			err := next(ctx, exchange)
+
+			attrs := []slog.Attr{
+				slog.String("command", exchange.Command),
+				slog.Duration("duration", time.Since(start)),
+				slog.Int("attempts", exchange.Attempts),
+				slog.Int("http_status", exchange.StatusCode),
+			}
+			level := slog.LevelInfo
+			if exchange.Response != nil {
+				attrs = append(attrs, slog.String("status", exchange.Response.Status))
+				if warnings := exchange.Response.Warnings; len(warnings) > 0 {
+					level = slog.LevelWarn
+					attrs = append(attrs, slog.String("warnings", strings.TrimSpace(warnings.Error())))
+				}
+			}
+			if err != nil {
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", err.Error()))
+				if numbers := errorNumbersOf(err); len(numbers) > 0 {
+					attrs = append(attrs, slog.Any("error_numbers", numbers))
+				}
+			}
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				attrs = append(attrs,
+					slog.String("params", RedactParams(exchange.Params).Encode()),
+					slog.String("body", RedactBody(exchange.Body)),
+				)
+			}
+
+			logger.LogAttrs(ctx, level, "namecheap api call", attrs...)
+			return err
+		}
+	}
+}
+
+// RedactParams returns a copy of params with credentials, transfer
+// authorization and promotion codes and contact details replaced by
+// Redacted.
+func RedactParams(params url.Values) url.Values {
+	redacted := url.Values{}
+	for k, v := range params {
+		if isSecretParam(k) {
+			redacted.Set(k, Redacted)
+			continue
+		}
+		redacted[k] = append([]string(nil), v...)
+	}
+	return redacted
+}
+
+// RedactBody returns an API response body with contact details replaced by
+// Redacted.
+func RedactBody(body []byte) string {
+	b := piiElementPattern.ReplaceAll(body, []byte("${1}"+Redacted+"${2}"))
+	b = piiAttrPattern.ReplaceAll(b, []byte("${1}"+Redacted+"${2}"))
+	return string(b)
+}
+
+func isSecretParam(name string) bool {
+	switch name {
+	case "ApiKey", "ApiUser", "UserName":
+		return true
+	case "EPPCode", "PromotionCode":
+		// Anyone holding the EPP code can transfer the domain away.
+		return true
+	}
+	for _, field := range piiFields {
+		if strings.HasSuffix(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorNumbersOf returns the Namecheap error numbers wrapped in err.
+func errorNumbersOf(err error) []int {
+	var apiErrs ApiErrors
+	if !errors.As(err, &apiErrs) {
+		return nil
+	}
+	numbers := make([]int, len(apiErrs))
+	for i, apiErr := range apiErrs {
+		numbers[i] = apiErr.Number
+	}
+	return numbers
+}
@@ -0,0 +1,116 @@
+package namecheap
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoggingMiddlewareRedacts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <RequestedCommand>namecheap.domains.create</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.create">
+    <DomainCreateResult Domain="example.com" Registered="true" ChargedAmount="20.8700" DomainID="9007" OrderID="196074" TransactionID="380716" WhoisguardEnable="false" NonRealTimeDomain="false" />
+    <Registrant><EmailAddress>joe.dirt1@gmail.com</EmailAddress></Registrant>
+    <DomainGetInfoResult Status="Ok" ID="9007" DomainName="example.com" OwnerName="anOwner" IsOwner="true" />
+    <Domain ID="9007" Name="example.com" User="anOwner" Created="02/15/2016" Expires="02/15/2017" />
+  </CommandResponse>
+</ApiResponse>`
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, respXML)
+	})
+
+	var buf bytes.Buffer
+	client.Middleware = nil
+	WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))(client)
+	client.NewRegistrant(
+		"Joe", "Dirt",
+		"10 Park Ave.", "",
+		"NY", "New York", "10001", "US",
+		"+1.9125357070", "joe.dirt1@gmail.com",
+	)
+
+	if _, err := client.DomainCreate("example.com", 1); err != nil {
+		t.Fatalf("DomainCreate returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"anToken", "anApiUser", "anUser", "joe.dirt1", "Dirt", "Park", "9125357070", "anOwner"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Log output contains %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"command=namecheap.domains.create", "level=INFO", "ChargedAmount", "DomainName=example.com", `OwnerName=\"` + Redacted, `User=\"` + Redacted} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Log output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoggingMiddlewareRedactsEPPCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse /></ApiResponse>`)
+	})
+
+	var buf bytes.Buffer
+	client.Middleware = nil
+	WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))(client)
+
+	if _, err := client.TransferCreate("example.com", 1, "s3cr3t", TransferCreateOption{PromotionCode: "MOVE"}); err != nil {
+		t.Fatalf("TransferCreate returned error: %v", err)
+	}
+	if _, err := client.TransferUpdateStatus(15, "s3cr3t"); err != nil {
+		t.Fatalf("TransferUpdateStatus returned error: %v", err)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"s3cr3t", "MOVE"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Log output contains %q:\n%s", secret, out)
+		}
+	}
+	if strings.Count(out, "EPPCode="+Redacted) != 2 {
+		t.Errorf("Log output does not redact both EPP codes:\n%s", out)
+	}
+}
+
+func TestLoggingMiddlewareErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+			<Errors>
+				<Error Number="2019166">Domain not found</Error>
+			</Errors>
+		</ApiResponse>`)
+	})
+
+	var buf bytes.Buffer
+	client.Middleware = []Middleware{LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil)))}
+
+	if _, err := client.DomainGetInfo("example.com"); err == nil {
+		t.Fatal("Expected error from DomainGetInfo")
+	}
+
+	out := buf.String()
+	for _, want := range []string{"level=ERROR", "error_numbers=[2019166]", "status=ERROR"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Log output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "body=") {
+		t.Errorf("Bodies should only be logged at debug level:\n%s", out)
+	}
+}
@@ -0,0 +1,96 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseMeta holds the metadata every API response carries next to the
+// command's result.
+type ResponseMeta struct {
+	// Command is the RequestedCommand echoed by the API.
+	Command string
+	// Server is the name of the API server which handled the call.
+	Server string
+	// GMTTimeDifference is the offset of the server's clock from GMT as
+	// reported by the API, e.g. "--5:00". See GMTOffset.
+	GMTTimeDifference string
+	// ExecutionTime is the time the server spent on the call.
+	ExecutionTime time.Duration
+	// Warnings are reported by the API for calls which otherwise succeeded.
+	Warnings ApiWarnings
+}
+
+// GMTOffset parses GMTTimeDifference, which Namecheap sends in forms such as
+// "+5", "-5:00" and "--5:00" (the doubled sign meaning negative).
+func (meta *ResponseMeta) GMTOffset() (time.Duration, error) {
+	s := strings.TrimSpace(meta.GMTTimeDifference)
+	if s == "" {
+		return 0, nil
+	}
+
+	sign := time.Duration(1)
+	if strings.HasPrefix(s, "-") {
+		sign = -1
+	}
+	s = strings.TrimLeft(s, "+-")
+
+	hours, minutes, _ := strings.Cut(s, ":")
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, fmt.Errorf("invalid GMTTimeDifference %q", meta.GMTTimeDifference)
+	}
+	var m int
+	if minutes != "" {
+		if m, err = strconv.Atoi(minutes); err != nil {
+			return 0, fmt.Errorf("invalid GMTTimeDifference %q", meta.GMTTimeDifference)
+		}
+	}
+	return sign * (time.Duration(h)*time.Hour + time.Duration(m)*time.Minute), nil
+}
+
+// ApiWarning is a warning returned in an API response.
+type ApiWarning struct {
+	Number  int    `xml:"Number,attr"`
+	Message string `xml:",innerxml"`
+}
+
+// ApiWarnings holds the warnings of a response. It implements error so
+// that a Client with StrictWarnings can return it, along with the result of
+// the call.
+type ApiWarnings []ApiWarning
+
+func (warnings ApiWarnings) Error() string {
+	msg := ""
+	for _, warning := range warnings {
+		msg += fmt.Sprintf("Warning %d: %s\n", warning.Number, warning.Message)
+	}
+	return msg
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context which makes any Context method called
+// with it store the metadata of its response in meta. It is filled in for
+// responses with Namecheap errors too.
+//
+//	var meta namecheap.ResponseMeta
+//	info, err := client.DomainGetInfoContext(namecheap.WithResponseMeta(ctx, &meta), "example.com")
+//	for _, w := range meta.Warnings { ... }
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// storeResponseMeta copies the metadata of resp to the ResponseMeta
+// requested with WithResponseMeta, if any.
+func storeResponseMeta(ctx context.Context, resp *ApiResponse) {
+	if resp == nil {
+		return
+	}
+	if meta, ok := ctx.Value(responseMetaKey{}).(*ResponseMeta); ok {
+		*meta = resp.ResponseMeta
+	}
+}
@@ -0,0 +1,130 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const warningResponseXML = `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <Warnings>
+    <Warning Number="3031510">Nameservers could not be updated</Warning>
+  </Warnings>
+  <RequestedCommand>namecheap.domains.getinfo</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getInfo">
+    <DomainGetInfoResult DomainName="example.com" />
+  </CommandResponse>
+  <Server>WEB1-SANDBOX1</Server>
+  <GMTTimeDifference>--5:00</GMTTimeDifference>
+  <ExecutionTime>0.25</ExecutionTime>
+</ApiResponse>`
+
+func TestWithResponseMeta(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, warningResponseXML)
+	})
+
+	var meta ResponseMeta
+	info, err := client.DomainGetInfoContext(WithResponseMeta(context.Background(), &meta), "example.com")
+	if err != nil {
+		t.Fatalf("DomainGetInfo returned error: %v", err)
+	}
+	if info.Name != "example.com" {
+		t.Errorf("DomainGetInfo returned %+v", info)
+	}
+
+	if meta.Command != "namecheap.domains.getinfo" || meta.Server != "WEB1-SANDBOX1" {
+		t.Errorf("Unexpected meta %+v", meta)
+	}
+	if meta.ExecutionTime != 250*time.Millisecond {
+		t.Errorf("ExecutionTime = %v, want 250ms", meta.ExecutionTime)
+	}
+	if len(meta.Warnings) != 1 || meta.Warnings[0].Number != 3031510 {
+		t.Errorf("Warnings = %+v", meta.Warnings)
+	}
+	if offset, err := meta.GMTOffset(); err != nil || offset != -5*time.Hour {
+		t.Errorf("GMTOffset() = %v, %v, want -5h", offset, err)
+	}
+}
+
+func TestStrictWarnings(t *testing.T) {
+	setup()
+	defer teardown()
+	client.StrictWarnings = true
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, warningResponseXML)
+	})
+
+	info, err := client.DomainGetInfo("example.com")
+	var warnings ApiWarnings
+	if !errors.As(err, &warnings) || warnings[0].Number != 3031510 {
+		t.Errorf("Expected ApiWarnings error, got %v", err)
+	}
+	if info == nil || info.Name != "example.com" {
+		t.Errorf("Expected the result along with the warnings, got %+v", info)
+	}
+}
+
+func TestStrictWarningsKeepCharge(t *testing.T) {
+	setup()
+	defer teardown()
+	client.StrictWarnings = true
+	client.NewRegistrant(
+		"r", "m",
+		"10 Park Ave.", "",
+		"NY", "New York", "10001", "US",
+		"+1.9125357070", "joe.dirt1@gmail.com",
+	)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <Warnings>
+    <Warning Number="3031510">Nameservers could not be updated</Warning>
+  </Warnings>
+  <CommandResponse Type="namecheap.domains.create">
+    <DomainCreateResult Domain="example.com" Registered="true" ChargedAmount="20.8700" DomainID="9007" OrderID="196074" TransactionID="380716" />
+  </CommandResponse>
+</ApiResponse>`)
+	})
+
+	result, err := client.DomainCreate("example.com", 1)
+	var warnings ApiWarnings
+	if !errors.As(err, &warnings) {
+		t.Errorf("Expected ApiWarnings error, got %v", err)
+	}
+	if result == nil || result.OrderID != 196074 || result.TransactionID != 380716 {
+		t.Errorf("Expected the order of the charged domain, got %+v", result)
+	}
+}
+
+func TestGMTOffset(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":       0,
+		"+5":     5 * time.Hour,
+		"-5:00":  -5 * time.Hour,
+		"--5:00": -5 * time.Hour,
+		"+5:30":  5*time.Hour + 30*time.Minute,
+	}
+	for in, want := range cases {
+		meta := ResponseMeta{GMTTimeDifference: in}
+		if got, err := meta.GMTOffset(); err != nil || got != want {
+			t.Errorf("GMTOffset(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+
+	meta := ResponseMeta{GMTTimeDifference: "five"}
+	if _, err := meta.GMTOffset(); err == nil {
+		t.Error("Expected error for an invalid GMTTimeDifference")
+	}
+}
@@ -0,0 +1,65 @@
+package namecheap
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Redacted replaces secrets in the parameters handed to middleware.
+const Redacted = "REDACTED"
+
+// Exchange is a single API call as seen by middleware.
+type Exchange struct {
+	// Command is the Namecheap command, e.g. "namecheap.domains.getInfo".
+	Command string
+
+	// Params are the request parameters with ApiKey set to Redacted.
+	// Middleware may change them before calling the next Handler, except
+	// for ApiUser, ApiKey, UserName, ClientIp and Command which are always
+	// set by the client.
+	Params url.Values
+
+	// Header holds extra HTTP headers sent with the request.
+	Header http.Header
+
+	// Attempts is the number of HTTP requests made so far, including retries.
+	Attempts int
+
+	// StatusCode and Body are the raw HTTP response of the last attempt.
+	StatusCode int
+	Body       []byte
+
+	// Response is the decoded envelope of the last attempt. It is set for
+	// Namecheap errors too, in which case the Handler also returns them.
+	Response *ApiResponse
+
+	// result receives the CommandResponse element, decoded is set once
+	// Body has been decoded into Response and result.
+	result  interface{}
+	decoded bool
+}
+
+// decode decodes Body into Response and the command's result, returning
+// the Namecheap errors of an ERROR response.
+func (exchange *Exchange) decode() error {
+	resp, err := decodeResponse(exchange.Body, exchange.result)
+	exchange.decoded = true
+	if err != nil {
+		return err
+	}
+
+	exchange.Response = resp
+	if resp.Status == "ERROR" {
+		return resp.Errors
+	}
+	return nil
+}
+
+// Handler performs an Exchange.
+type Handler func(ctx context.Context, exchange *Exchange) error
+
+// Middleware wraps a Handler. It can inspect or modify the exchange before
+// and after calling next, or short-circuit the call by setting exchange.Body
+// to a response document and returning without calling next.
+type Middleware func(next Handler) Handler
@@ -0,0 +1,93 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewareOrderAndExchange(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") != "abc" {
+			t.Errorf("X-Request-Id = %v, want abc", r.Header.Get("X-Request-Id"))
+		}
+		if r.FormValue("ApiKey") != "anToken" {
+			t.Errorf("ApiKey = %v, want the real token to be sent", r.FormValue("ApiKey"))
+		}
+		if r.FormValue("DomainName") != "example.org" {
+			t.Errorf("DomainName = %v, want the middleware's change to be sent", r.FormValue("DomainName"))
+		}
+		fmt.Fprint(w, okResponseXML)
+	})
+
+	var order []string
+	var seen *Exchange
+	client.Middleware = []Middleware{
+		func(next Handler) Handler {
+			return func(ctx context.Context, exchange *Exchange) error {
+				order = append(order, "outer")
+				if got := exchange.Params.Get("ApiKey"); got != Redacted {
+					t.Errorf("ApiKey = %v, want %v", got, Redacted)
+				}
+				exchange.Header.Set("X-Request-Id", "abc")
+				err := next(ctx, exchange)
+				seen = exchange
+				return err
+			}
+		},
+		func(next Handler) Handler {
+			return func(ctx context.Context, exchange *Exchange) error {
+				order = append(order, "inner")
+				exchange.Params.Set("DomainName", "example.org")
+				return next(ctx, exchange)
+			}
+		},
+	}
+
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Fatalf("DomainGetInfo returned error: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"outer", "inner"}) {
+		t.Errorf("Middleware ran in order %v", order)
+	}
+	if seen.Command != domainsGetInfo || seen.StatusCode != http.StatusOK || seen.Attempts != 1 {
+		t.Errorf("Unexpected exchange %+v", seen)
+	}
+	if seen.Response == nil || seen.Response.Status != "OK" || len(seen.Body) == 0 {
+		t.Errorf("Expected the raw and decoded response, got %+v", seen)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not reach the API")
+	})
+	client.Middleware = []Middleware{
+		func(next Handler) Handler {
+			return func(ctx context.Context, exchange *Exchange) error {
+				exchange.Body = []byte(`<ApiResponse Status="OK">
+					<CommandResponse Type="namecheap.domains.getInfo">
+						<DomainGetInfoResult DomainName="cached.com" />
+					</CommandResponse>
+				</ApiResponse>`)
+				return nil
+			}
+		},
+	}
+
+	info, err := client.DomainGetInfo("cached.com")
+	if err != nil {
+		t.Fatalf("DomainGetInfo returned error: %v", err)
+	}
+	if info.Name != "cached.com" {
+		t.Errorf("DomainGetInfo returned %+v", info)
+	}
+}
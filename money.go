@@ -0,0 +1,204 @@
+package namecheap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of amounts the API reports without one,
+// such as ChargedAmount.
+const DefaultCurrency = "USD"
+
+// Money keeps amounts exactly to moneyDecimals places, as integer multiples
+// of 1/moneyScale.
+const (
+	moneyDecimals = 4
+	moneyScale    = 10000
+)
+
+// Money is an exact decimal amount in a currency, kept to 4 decimal places,
+// the precision the API reports amounts with. The zero value is an amount of
+// zero in no particular currency, which adopts the currency of whatever it
+// is added to, so totals can start from it:
+//
+//	var total namecheap.Money
+//	for _, r := range renewals {
+//		if total, err = total.Add(r.ChargedAmount); err != nil { ... }
+//	}
+type Money struct {
+	units    int64 // 1/moneyScale of the currency unit
+	Currency string
+}
+
+// ParseMoney parses a decimal amount such as "20.8700" or "-3.5".
+// It fails if the amount has more than 4 significant decimal places.
+func ParseMoney(amount, currency string) (Money, error) {
+	s := strings.TrimSpace(amount)
+	if s == "" {
+		return Money{Currency: currency}, nil
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	if neg || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	whole, frac, point := strings.Cut(s, ".")
+	if whole == "" && frac == "" || point && frac == "" {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > moneyDecimals {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places", amount, moneyDecimals)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	digits := whole + frac + strings.Repeat("0", moneyDecimals-len(frac))
+	if strings.Trim(digits, "0123456789") != "" {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	if neg {
+		units = -units
+	}
+	return Money{units: units, Currency: currency}, nil
+}
+
+func (m *Money) UnmarshalXMLAttr(attr xml.Attr) error {
+	currency := m.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	parsed, err := ParseMoney(attr.Value, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalText formats m as by String, e.g. "20.87 USD", so that results
+// keep their amounts when encoded to JSON.
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses an amount formatted by MarshalText.
+func (m *Money) UnmarshalText(text []byte) error {
+	amount, currency, _ := strings.Cut(strings.TrimSpace(string(text)), " ")
+	parsed, err := ParseMoney(amount, strings.TrimSpace(currency))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Amount formats the amount with at least 2 decimal places, e.g. "20.87".
+func (m Money) Amount() string {
+	units := m.units
+	sign := ""
+	if units < 0 {
+		sign, units = "-", -units
+	}
+	frac := strings.TrimRight(fmt.Sprintf("%04d", units%moneyScale), "0")
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	return fmt.Sprintf("%s%d.%s", sign, units/moneyScale, frac)
+}
+
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount()
+	}
+	return m.Amount() + " " + m.Currency
+}
+
+// Float64 returns the amount as a float64, which may not be exact.
+func (m Money) Float64() float64 {
+	return float64(m.units) / moneyScale
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// currencyWith returns the currency of the result of an operation on m and
+// other, or an error if they are in different currencies.
+func (m Money) currencyWith(other Money) (string, error) {
+	switch {
+	case m.Currency == other.Currency || other.Currency == "":
+		return m.Currency, nil
+	case m.Currency == "":
+		return other.Currency, nil
+	}
+	return "", fmt.Errorf("currency mismatch: %s and %s", m.Currency, other.Currency)
+}
+
+// Add returns m + other.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.currencyWith(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: m.units + other.units, Currency: currency}, nil
+}
+
+// Sub returns m - other.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.currencyWith(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: m.units - other.units, Currency: currency}, nil
+}
+
+// Mul returns m multiplied by n, e.g. a yearly price by a number of years.
+func (m Money) Mul(n int64) Money {
+	return Money{units: m.units * n, Currency: m.Currency}
+}
+
+// Cmp compares m and other, returning -1, 0 or +1.
+func (m Money) Cmp(other Money) (int, error) {
+	if _, err := m.currencyWith(other); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.units < other.units:
+		return -1, nil
+	case m.units > other.units:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Equal reports whether m and other are the same amount in the same currency.
+func (m Money) Equal(other Money) bool {
+	c, err := m.Cmp(other)
+	return err == nil && c == 0
+}
+
+// SumMoney adds up amounts, which must all be in the same currency.
+func SumMoney(amounts ...Money) (Money, error) {
+	var total Money
+	for _, amount := range amounts {
+		var err error
+		if total, err = total.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
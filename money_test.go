@@ -0,0 +1,139 @@
+package namecheap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func mustMoney(amount string) Money {
+	m, err := ParseMoney(amount, DefaultCurrency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestParseMoney(t *testing.T) {
+	cases := map[string]string{
+		"20.8700": "20.87",
+		"0.1":     "0.10",
+		"-3.5":    "-3.50",
+		"650":     "650.00",
+		".9999":   "0.9999",
+		"":        "0.00",
+	}
+	for in, want := range cases {
+		m, err := ParseMoney(in, "USD")
+		if err != nil || m.Amount() != want {
+			t.Errorf("ParseMoney(%q) = %v, %v, want %v", in, m.Amount(), err, want)
+		}
+	}
+
+	for _, in := range []string{"1.23456", "abc", "1.2.3", "+-5", "--5", "5.-1", ".", "1.", "-", "+."} {
+		if _, err := ParseMoney(in, "USD"); err == nil {
+			t.Errorf("Expected error for %q", in)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	b, err := json.Marshal(DomainCreateResult{ChargedAmount: mustMoney("20.87")})
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if !strings.Contains(string(b), `"ChargedAmount":"20.87 USD"`) {
+		t.Errorf("Marshal lost the charge: %s", b)
+	}
+
+	var result DomainCreateResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if !result.ChargedAmount.Equal(mustMoney("20.87")) {
+		t.Errorf("Unmarshal returned %v, want 20.87 USD", result.ChargedAmount)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	// 0.1 added ten times is exactly 1, unlike with float64.
+	var total Money
+	for i := 0; i < 10; i++ {
+		var err error
+		if total, err = total.Add(mustMoney("0.1")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !total.Equal(mustMoney("1")) || total.String() != "1.00 USD" {
+		t.Errorf("total = %v, want 1.00 USD", total)
+	}
+
+	sum, err := SumMoney(mustMoney("20.87"), mustMoney("8.88").Mul(3))
+	if err != nil || sum.Amount() != "47.51" {
+		t.Errorf("SumMoney = %v, %v, want 47.51", sum, err)
+	}
+	if diff, _ := sum.Sub(mustMoney("50")); !diff.IsNegative() || diff.Amount() != "-2.49" {
+		t.Errorf("Sub = %v, want -2.49", diff)
+	}
+	if c, err := sum.Cmp(mustMoney("47.5")); err != nil || c != 1 {
+		t.Errorf("Cmp = %v, %v, want 1", c, err)
+	}
+
+	eur, _ := ParseMoney("1", "EUR")
+	if _, err := sum.Add(eur); err == nil {
+		t.Error("Expected error adding different currencies")
+	}
+	if sum.Equal(eur) {
+		t.Error("Amounts in different currencies should not be equal")
+	}
+}
+
+func TestUsersGetBalancesCurrency(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.getBalances</RequestedCommand>
+  <CommandResponse Type="namecheap.users.getBalances">
+    <UserGetBalancesResult Currency="EUR" AvailableBalance="4932.96" AccountBalance="4932.96" EarnedAmount="381.70" WithdrawableAmount="1243.36" FundsRequiredForAutoRenew="0.00" />
+  </CommandResponse>
+</ApiResponse>`)
+	})
+
+	balances, err := client.UsersGetBalances()
+	if err != nil {
+		t.Fatalf("UsersGetBalances returned error: %v", err)
+	}
+	if got := balances[0].AvailableBalance.String(); got != "4932.96 EUR" {
+		t.Errorf("AvailableBalance = %v, want 4932.96 EUR", got)
+	}
+}
+
+func TestUsersGetBalancesDefaultCurrency(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.users.getBalances</RequestedCommand>
+  <CommandResponse Type="namecheap.users.getBalances">
+    <UserGetBalancesResult AvailableBalance="4932.96" AccountBalance="4932.96" EarnedAmount="381.70" WithdrawableAmount="1243.36" FundsRequiredForAutoRenew="0.00" />
+  </CommandResponse>
+</ApiResponse>`)
+	})
+
+	balances, err := client.UsersGetBalances()
+	if err != nil {
+		t.Fatalf("UsersGetBalances returned error: %v", err)
+	}
+	if got := balances[0].AvailableBalance.Currency; got != DefaultCurrency {
+		t.Errorf("AvailableBalance.Currency = %q, want %q", got, DefaultCurrency)
+	}
+}
@@ -1,22 +1,33 @@
 // Package namecheap implements a client for the Namecheap API.
 //
 // In order to use this package you will need a Namecheap account and your API Token.
+//
+// Every API method has a variant suffixed with Context (for example
+// DomainGetInfoContext) which takes a context.Context as its first argument.
+// The context controls cancellation and deadlines of the underlying HTTP
+// request; when it is done the method returns ctx.Err(), so callers can use
+// errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded).
+// The methods without the suffix use context.Background().
 package namecheap
 
 import (
-	"encoding/xml"
+	"context"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-const defaultBaseURL = "https://api.namecheap.com/xml.response"
+const (
+	defaultBaseURL = "https://api.namecheap.com/xml.response"
+	sandboxBaseURL = "https://api.sandbox.namecheap.com/xml.response"
+)
 
 // Client represents a client used to make calls to the Namecheap API.
+// It is safe for concurrent use once configured.
 type Client struct {
 	ApiUser    string
 	ApiToken   string
@@ -29,154 +40,276 @@ type Client struct {
 	// BaseURL should always be specified with a trailing slash.
 	BaseURL string
 
-	*Registrant
-}
+	// UserAgent, if set, is sent as the User-Agent header.
+	UserAgent string
 
-type ApiRequest struct {
-	method  string
-	command string
-	params  url.Values
-}
+	// RetryPolicy controls whether failed calls are retried.
+	// A nil RetryPolicy disables retries.
+	RetryPolicy *RetryPolicy
 
-type Paging struct {
-	TotalItems  int `xml:"TotalItems"`
-	CurrentPage int `xml:"CurrentPage"`
-	PageSize    int `xml:"PageSize"`
-}
+	// ClientIP is sent as the ClientIp parameter, which Namecheap checks
+	// against the account's whitelist. If empty, it is discovered once with
+	// IPResolver, or 127.0.0.1 is sent when IPResolver is nil too.
+	ClientIP   string
+	IPResolver IPResolver
 
-type ApiResponse struct {
-	Status             string                    `xml:"Status,attr"`
-	Command            string                    `xml:"RequestedCommand"`
-	TLDList            []TLDListResult           `xml:"CommandResponse>Tlds>Tld"`
-	AddressGetList     []AddressGetListResult    `xml:"CommandResponse>AddressGetListResult>List"`
-	AddressGetInfo     *AddressGetInfoResult     `xml:"CommandResponse>GetAddressInfoResult"`
-	Domains            []DomainGetListResult     `xml:"CommandResponse>DomainGetListResult>Domain"`
-	DomainInfo         *DomainInfo               `xml:"CommandResponse>DomainGetInfoResult"`
-	DomainDNSHosts     *DomainDNSGetHostsResult  `xml:"CommandResponse>DomainDNSGetHostsResult"`
-	DomainDNSSetHosts  *DomainDNSSetHostsResult  `xml:"CommandResponse>DomainDNSSetHostsResult"`
-	DomainCreate       *DomainCreateResult       `xml:"CommandResponse>DomainCreateResult"`
-	DomainRenew        *DomainRenewResult        `xml:"CommandResponse>DomainRenewResult"`
-	DomainsCheck       []DomainCheckResult       `xml:"CommandResponse>DomainCheckResult"`
-	DomainNSInfo       *DomainNSInfoResult       `xml:"CommandResponse>DomainNSInfoResult"`
-	DomainDNSSetCustom *DomainDNSSetCustomResult `xml:"CommandResponse>DomainDNSSetCustomResult"`
-	DomainContacts     *DomainGetContactsResult  `xml:"CommandResponse>DomainContactsResult"`
-	UsersGetPricing    []UsersGetPricingResult   `xml:"CommandResponse>UserGetPricingResult>ProductType"`
-	UsersGetBalances   []UsersGetBalancesResult  `xml:"CommandResponse>UserGetBalancesResult"`
-	WhoisguardList     []WhoisguardGetListResult `xml:"CommandResponse>WhoisguardGetListResult>Whoisguard"`
-	WhoisguardEnable   whoisguardEnableResult    `xml:"CommandResponse>WhoisguardEnableResult"`
-	WhoisguardDisable  whoisguardDisableResult   `xml:"CommandResponse>WhoisguardDisableResult"`
-	WhoisguardRenew    *WhoisguardRenewResult    `xml:"CommandResponse>WhoisguardRenewResult"`
-	Paging             *Paging                   `xml:"CommandResponse>Paging"`
-	Errors             ApiErrors                 `xml:"Errors>Error"`
-}
+	// RateLimiter, if set, is consulted before every request (including
+	// retries). Share one RateLimiter between all clients of an account.
+	RateLimiter *RateLimiter
 
-// ApiError is the format of the error returned in the api responses.
-type ApiError struct {
-	Number  int    `xml:"Number,attr"`
-	Message string `xml:",innerxml"`
-}
+	// StrictWarnings makes calls whose response carries warnings return the
+	// ApiWarnings as error. The call did succeed though, so its result is
+	// returned along with the error: a command which charged the account
+	// still reports its order and transaction.
+	StrictWarnings bool
 
-func (err *ApiError) Error() string {
-	return err.Message
-}
+	// Middleware wraps every call, the first one being the outermost.
+	Middleware []Middleware
 
-// ApiErrors holds multiple ApiError's but implements the error interface
-type ApiErrors []ApiError
+	// DomainParser splits the full domain names taken by methods such as
+	// DomainsDNSGetHostsByName. If nil, any TLD in the Public Suffix List
+	// is accepted.
+	DomainParser *DomainParser
 
-func (errs ApiErrors) Error() string {
-	errMsg := ""
-	for _, apiError := range errs {
-		errMsg += fmt.Sprintf("Error %d: %s\n", apiError.Number, apiError.Message)
-	}
-	return errMsg
+	// Registrant holds the default contacts of commands taking contacts,
+	// used when a call does not provide its own. Once the client is shared
+	// between goroutines, replace it with SetRegistrant rather than
+	// modifying it in place.
+	*Registrant
+
+	regMu      sync.RWMutex
+	ipMu       sync.Mutex
+	resolvedIP string
+}
+
+type ApiRequest struct {
+	method  string
+	command string
+	params  url.Values
+	header  http.Header
 }
 
-func NewClient(apiUser, apiToken, userName string) *Client {
-	return &Client{
+// NewClient returns a client for the given account, talking to the public
+// Namecheap API unless options say otherwise. Options are applied in order.
+func NewClient(apiUser, apiToken, userName string, opts ...ClientOption) *Client {
+	client := &Client{
 		ApiUser:    apiUser,
 		ApiToken:   apiToken,
 		UserName:   userName,
 		HttpClient: http.DefaultClient,
 		BaseURL:    defaultBaseURL,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
 }
 
-// NewRegistrant associates a new registrant with the
+// NewRegistrant sets the default contacts of the client to a new registrant,
+// as built by the NewRegistrant function.
 func (client *Client) NewRegistrant(
 	firstName, lastName,
 	addr1, addr2,
 	city, state, postalCode, country,
 	phone, email string,
 ) {
-	client.Registrant = newRegistrant(
+	client.SetRegistrant(NewRegistrant(
 		firstName, lastName,
 		addr1, addr2,
 		city, state, postalCode, country,
 		phone, email,
-	)
+	))
+}
+
+// SetRegistrant sets the default contacts of the client to a copy of reg,
+// or clears them if reg is nil. It may be called while other goroutines use
+// the client; calls already started keep the contacts they began with.
+func (client *Client) SetRegistrant(reg *Registrant) {
+	if reg != nil {
+		copied := *reg
+		reg = &copied
+	}
+	client.regMu.Lock()
+	client.Registrant = reg
+	client.regMu.Unlock()
+}
+
+// contacts returns reg, or a copy of the default contacts when reg is nil.
+func (client *Client) contacts(reg *Registrant) (*Registrant, error) {
+	if reg != nil {
+		return reg, nil
+	}
+	client.regMu.RLock()
+	defer client.regMu.RUnlock()
+	if client.Registrant == nil {
+		return nil, errors.New("Registrant information on client cannot be empty")
+	}
+	copied := *client.Registrant
+	return &copied, nil
 }
 
-func (client *Client) do(request *ApiRequest) (*ApiResponse, error) {
+// do sends the request and decodes the CommandResponse element of the
+// response into result, which may be nil. With StrictWarnings, the error is
+// the ApiWarnings of a call which succeeded, result being decoded.
+func (client *Client) do(ctx context.Context, request *ApiRequest, result interface{}) (*ApiResponse, error) {
 	if request.method == "" {
 		return nil, errors.New("request method cannot be blank")
 	}
 
-	body, status, err := client.sendRequest(request)
+	params := url.Values{}
+	for k, v := range request.params {
+		params[k] = append([]string(nil), v...)
+	}
+	if err := encodeDomainParams(params); err != nil {
+		return nil, err
+	}
+	clientIP, err := client.clientIP(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if status != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code from api: %d - %s", status, body)
+	params.Set("ApiUser", client.ApiUser)
+	params.Set("ApiKey", Redacted)
+	params.Set("UserName", client.UserName)
+	params.Set("ClientIp", clientIP)
+	params.Set("Command", request.command)
+
+	exchange := &Exchange{
+		Command: request.command,
+		Params:  params,
+		Header:  http.Header{},
+		result:  result,
+	}
+	handler := client.handle(request.method)
+	for i := len(client.Middleware) - 1; i >= 0; i-- {
+		handler = client.Middleware[i](handler)
 	}
 
-	resp := new(ApiResponse)
-	if err = xml.Unmarshal(body, resp); err != nil {
+	err = handler(ctx, exchange)
+	if err == nil && !exchange.decoded {
+		// A middleware answered without calling the API.
+		err = exchange.decode()
+	}
+	storeResponseMeta(ctx, exchange.Response)
+	if err != nil {
 		return nil, err
 	}
+	if client.StrictWarnings && len(exchange.Response.Warnings) > 0 {
+		return exchange.Response, exchange.Response.Warnings
+	}
+	return exchange.Response, nil
+}
+
+// failed reports whether err, as returned by do, means the call failed
+// rather than succeeded with warnings.
+func failed(err error) bool {
+	var warnings ApiWarnings
+	return err != nil && !errors.As(err, &warnings)
+}
 
-	if resp.Status == "" {
-		return nil, errors.New("failed to parse xml from api")
+// handle returns the innermost Handler, which sends the exchange to the API,
+// retrying as the RetryPolicy allows.
+func (client *Client) handle(method string) Handler {
+	return func(ctx context.Context, exchange *Exchange) error {
+		for attempt := 1; ; attempt++ {
+			if err := client.RateLimiter.acquire(ctx); err != nil {
+				return err
+			}
+
+			request := &ApiRequest{
+				method:  method,
+				command: exchange.Command,
+				params:  url.Values{},
+				header:  exchange.Header,
+			}
+			for k, v := range exchange.Params {
+				request.params[k] = append([]string(nil), v...)
+			}
+
+			exchange.Attempts = attempt
+			err := client.doOnce(ctx, request, exchange)
+			if err != nil {
+				err = diagnoseClientIP(err, request.params.Get("ClientIp"))
+			}
+			if err == nil || !client.RetryPolicy.shouldRetry(exchange.Command, attempt, err) {
+				return err
+			}
+			if err := sleepContext(ctx, client.RetryPolicy.backoff(attempt)); err != nil {
+				return err
+			}
+		}
 	}
-	if resp.Status == "ERROR" {
-		return nil, resp.Errors
+}
+
+func (client *Client) doOnce(ctx context.Context, request *ApiRequest, exchange *Exchange) error {
+	exchange.StatusCode, exchange.Body, exchange.Response = 0, nil, nil
+
+	body, status, err := client.sendRequest(ctx, request)
+	if err != nil {
+		return err
+	}
+	exchange.StatusCode, exchange.Body = status, body
+	if status != http.StatusOK {
+		return &HTTPStatusError{StatusCode: status, Body: body}
 	}
 
-	return resp, nil
+	return exchange.decode()
 }
 
-func (client *Client) makeRequest(request *ApiRequest) (*http.Request, error) {
+func (client *Client) makeRequest(ctx context.Context, request *ApiRequest) (*http.Request, error) {
+	clientIP, err := client.clientIP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	p := request.params
 	p.Set("ApiUser", client.ApiUser)
 	p.Set("ApiKey", client.ApiToken)
 	p.Set("UserName", client.UserName)
-	// This param is required by the API, but not actually used.
-	p.Set("ClientIp", "127.0.0.1")
+	p.Set("ClientIp", clientIP)
 	p.Set("Command", request.command)
 
 	b := p.Encode()
-	req, err := http.NewRequest(request.method, client.BaseURL, strings.NewReader(b))
+	req, err := http.NewRequestWithContext(ctx, request.method, client.BaseURL, strings.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range request.header {
+		req.Header[k] = v
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Content-Length", strconv.Itoa(len(b)))
+	if client.UserAgent != "" {
+		req.Header.Set("User-Agent", client.UserAgent)
+	}
 	return req, nil
 }
 
-func (client *Client) sendRequest(request *ApiRequest) ([]byte, int, error) {
-	req, err := client.makeRequest(request)
+func (client *Client) sendRequest(ctx context.Context, request *ApiRequest) ([]byte, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	req, err := client.makeRequest(ctx, request)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	resp, err := client.HttpClient.Do(req)
 	if err != nil {
+		// Surface cancellation and deadlines as the context's own error so
+		// callers can tell them apart from transport failures.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, ctxErr
+		}
 		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
 	buf, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, 0, ctxErr
+		}
 		return nil, 0, err
 	}
 
@@ -1,12 +1,15 @@
 package namecheap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 )
 
 var (
@@ -62,6 +65,37 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClientOptions(t *testing.T) {
+	c := NewClient("anApiUser", "anToken", "anUser",
+		WithSandbox(),
+		WithUserAgent("acme-tools/1.0"),
+		WithClientIP("203.0.113.7"),
+		WithTimeout(5*time.Second),
+	)
+
+	if c.BaseURL != sandboxBaseURL {
+		t.Errorf("NewClient BaseURL = %v, want %v", c.BaseURL, sandboxBaseURL)
+	}
+	if c.HttpClient == http.DefaultClient || c.HttpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected a copy of the default HTTP client with a timeout, got %+v", c.HttpClient)
+	}
+	if http.DefaultClient.Timeout != 0 {
+		t.Error("WithTimeout modified http.DefaultClient")
+	}
+
+	req, _ := c.makeRequest(context.Background(), &ApiRequest{
+		method:  "POST",
+		command: "namecheap.domains.getList",
+		params:  url.Values{},
+	})
+	if ua := req.Header.Get("User-Agent"); ua != "acme-tools/1.0" {
+		t.Errorf("User-Agent = %v, want acme-tools/1.0", ua)
+	}
+	if req.FormValue("ClientIp") != "203.0.113.7" {
+		t.Errorf("ClientIp = %v, want 203.0.113.7", req.FormValue("ClientIp"))
+	}
+}
+
 // Verify that the MakeRequest function assembles the correct API URL
 func TestMakeRequest(t *testing.T) {
 	c := NewClient("anApiUser", "anToken", "anUser")
@@ -71,7 +105,7 @@ func TestMakeRequest(t *testing.T) {
 		command: "namecheap.domains.getList",
 		params:  url.Values{},
 	}
-	req, _ := c.makeRequest(requestInfo)
+	req, _ := c.makeRequest(context.Background(), requestInfo)
 
 	// correctly assembled URL
 	outURL := "https://fake-api-server/"
@@ -113,25 +147,25 @@ func TestDo(t *testing.T) {
 		command: "namecheap.domains.getList",
 		params:  url.Values{},
 	}
-	_, err := client.do(requestInfo)
+	_, err := client.do(context.Background(), requestInfo, nil)
 	if err == nil {
 		t.Errorf("Expected error for non-200 response, got %v", err)
 	}
 
 	state = "invalid"
-	_, err = client.do(requestInfo)
+	_, err = client.do(context.Background(), requestInfo, nil)
 	if err == nil {
 		t.Errorf("Expected error for invalid response, got %v", err)
 	}
 
 	state = "error"
-	_, err = client.do(requestInfo)
+	_, err = client.do(context.Background(), requestInfo, nil)
 	if err == nil || err.Error() != "Error 1: Some Error\n" {
 		t.Errorf("Expected error for error response, got %v", err)
 	}
 
 	state = "ok"
-	resp, err := client.do(requestInfo)
+	resp, err := client.do(context.Background(), requestInfo, nil)
 	if err != nil {
 		t.Errorf("Expected nil error, got %v", err)
 	}
@@ -139,3 +173,27 @@ func TestDo(t *testing.T) {
 		t.Errorf("Expected non-nil response, got %v", resp)
 	}
 }
+
+// Verify that a cancelled context aborts the request and is reported as such
+func TestDoContextCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	release := make(chan struct{})
+	defer close(release)
+	ctx, cancel := context.WithCancel(context.Background())
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-release
+	})
+
+	_, err := client.DomainGetInfoContext(ctx, "example.com")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	_, err = client.DomainGetInfoContext(ctx, "example.com")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for already cancelled context, got %v", err)
+	}
+}
@@ -1,6 +1,11 @@
 package namecheap
 
-import "net/url"
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+)
 
 const (
 	nsCreate  = "namecheap.domains.ns.create"
@@ -16,7 +21,31 @@ type DomainNSInfoResult struct {
 	Statuses   []string `xml:"NameserverStatuses>Status"`
 }
 
+type DomainNSCreateResult struct {
+	Domain     string `xml:"Domain,attr"`
+	Nameserver string `xml:"Nameserver,attr"`
+	IP         string `xml:"IP,attr"`
+	IsSuccess  bool   `xml:"IsSuccess,attr"`
+}
+
+type DomainNSUpdateResult struct {
+	Domain     string `xml:"Domain,attr"`
+	Nameserver string `xml:"Nameserver,attr"`
+	IsSuccess  bool   `xml:"IsSuccess,attr"`
+}
+
+type DomainNSDeleteResult struct {
+	Domain     string `xml:"Domain,attr"`
+	Nameserver string `xml:"Nameserver,attr"`
+	IsSuccess  bool   `xml:"IsSuccess,attr"`
+}
+
 func (client *Client) NSGetInfo(sld, tld, nameserver string) (*DomainNSInfoResult, error) {
+	return client.NSGetInfoContext(context.Background(), sld, tld, nameserver)
+}
+
+// NSGetInfoContext is like NSGetInfo but takes a context.
+func (client *Client) NSGetInfoContext(ctx context.Context, sld, tld, nameserver string) (*DomainNSInfoResult, error) {
 	requestInfo := &ApiRequest{
 		command: nsGetInfo,
 		method:  "POST",
@@ -26,10 +55,134 @@ func (client *Client) NSGetInfo(sld, tld, nameserver string) (*DomainNSInfoResul
 	requestInfo.params.Set("TLD", tld)
 	requestInfo.params.Set("Nameserver", nameserver)
 
-	resp, err := client.do(requestInfo)
+	var result struct {
+		DomainNSInfo *DomainNSInfoResult `xml:"DomainNSInfoResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainNSInfo != nil {
+		result.DomainNSInfo.Domain = unicodeDomain(result.DomainNSInfo.Domain)
+	}
+	return result.DomainNSInfo, err
+}
+
+// NSGetInfoByName is like NSGetInfo but takes a full domain name, split with
+// the client's DomainParser.
+func (client *Client) NSGetInfoByName(domainName, nameserver string) (*DomainNSInfoResult, error) {
+	return client.NSGetInfoByNameContext(context.Background(), domainName, nameserver)
+}
+
+// NSGetInfoByNameContext is like NSGetInfoByName but takes a context.
+func (client *Client) NSGetInfoByNameContext(ctx context.Context, domainName, nameserver string) (*DomainNSInfoResult, error) {
+	name, err := client.DomainParser.Parse(domainName)
 	if err != nil {
 		return nil, err
 	}
+	return client.NSGetInfoContext(ctx, name.SLD, name.TLD, nameserver)
+}
+
+// NSCreate creates a nameserver under a domain, such as ns1.example.com for
+// example.com, with ip as its glue record.
+func (client *Client) NSCreate(sld, tld, nameserver, ip string) (*DomainNSCreateResult, error) {
+	return client.NSCreateContext(context.Background(), sld, tld, nameserver, ip)
+}
+
+// NSCreateContext is like NSCreate but takes a context.
+func (client *Client) NSCreateContext(ctx context.Context, sld, tld, nameserver, ip string) (*DomainNSCreateResult, error) {
+	if net.ParseIP(ip) == nil {
+		return nil, fmt.Errorf("invalid IP address %q", ip)
+	}
+	requestInfo := &ApiRequest{
+		command: nsCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+	requestInfo.params.Set("Nameserver", nameserver)
+	requestInfo.params.Set("IP", ip)
+
+	var result struct {
+		DomainNSCreate *DomainNSCreateResult `xml:"DomainNSCreateResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainNSCreate != nil {
+		result.DomainNSCreate.Domain = unicodeDomain(result.DomainNSCreate.Domain)
+	}
+	return result.DomainNSCreate, err
+}
+
+// NSUpdate changes the IP address of a nameserver created with NSCreate
+// from oldIP to ip.
+func (client *Client) NSUpdate(sld, tld, nameserver, oldIP, ip string) (*DomainNSUpdateResult, error) {
+	return client.NSUpdateContext(context.Background(), sld, tld, nameserver, oldIP, ip)
+}
+
+// NSUpdateContext is like NSUpdate but takes a context.
+func (client *Client) NSUpdateContext(ctx context.Context, sld, tld, nameserver, oldIP, ip string) (*DomainNSUpdateResult, error) {
+	for _, addr := range []string{oldIP, ip} {
+		if net.ParseIP(addr) == nil {
+			return nil, fmt.Errorf("invalid IP address %q", addr)
+		}
+	}
+	requestInfo := &ApiRequest{
+		command: nsUpdate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+	requestInfo.params.Set("Nameserver", nameserver)
+	requestInfo.params.Set("OldIP", oldIP)
+	requestInfo.params.Set("IP", ip)
+
+	var result struct {
+		DomainNSUpdate *DomainNSUpdateResult `xml:"DomainNSUpdateResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
 
-	return resp.DomainNSInfo, nil
+	if result.DomainNSUpdate != nil {
+		result.DomainNSUpdate.Domain = unicodeDomain(result.DomainNSUpdate.Domain)
+	}
+	return result.DomainNSUpdate, err
+}
+
+// NSDelete deletes a nameserver created with NSCreate.
+func (client *Client) NSDelete(sld, tld, nameserver string) (*DomainNSDeleteResult, error) {
+	return client.NSDeleteContext(context.Background(), sld, tld, nameserver)
+}
+
+// NSDeleteContext is like NSDelete but takes a context.
+func (client *Client) NSDeleteContext(ctx context.Context, sld, tld, nameserver string) (*DomainNSDeleteResult, error) {
+	requestInfo := &ApiRequest{
+		command: nsDelete,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("SLD", sld)
+	requestInfo.params.Set("TLD", tld)
+	requestInfo.params.Set("Nameserver", nameserver)
+
+	var result struct {
+		DomainNSDelete *DomainNSDeleteResult `xml:"DomainNSDeleteResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.DomainNSDelete != nil {
+		result.DomainNSDelete.Domain = unicodeDomain(result.DomainNSDelete.Domain)
+	}
+	return result.DomainNSDelete, err
 }
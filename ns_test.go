@@ -55,3 +55,70 @@ func TestNSGetInfo(t *testing.T) {
 		t.Errorf("NSGetInfo returned %+v, want %+v", ns, want)
 	}
 }
+
+func TestNSCreateUpdateDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var correctParams url.Values
+	var respXML string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+	nsParams := func(command string) url.Values {
+		p := fillDefaultParams(url.Values{})
+		p.Set("Command", command)
+		p.Set("SLD", "domain")
+		p.Set("TLD", "com")
+		p.Set("Nameserver", "ns1.domain.com")
+		return p
+	}
+
+	correctParams = nsParams("namecheap.domains.ns.create")
+	correctParams.Set("IP", "192.0.2.1")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.ns.create">
+		<DomainNSCreateResult Domain="domain.com" Nameserver="ns1.domain.com" IP="192.0.2.1" IsSuccess="true" />
+	</CommandResponse></ApiResponse>`
+	created, err := client.NSCreate("domain", "com", "ns1.domain.com", "192.0.2.1")
+	if err != nil {
+		t.Fatalf("NSCreate returned error: %v", err)
+	}
+	if want := (&DomainNSCreateResult{"domain.com", "ns1.domain.com", "192.0.2.1", true}); !reflect.DeepEqual(created, want) {
+		t.Errorf("NSCreate returned %+v, want %+v", created, want)
+	}
+
+	correctParams = nsParams("namecheap.domains.ns.update")
+	correctParams.Set("OldIP", "192.0.2.1")
+	correctParams.Set("IP", "192.0.2.2")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.ns.update">
+		<DomainNSUpdateResult Domain="domain.com" Nameserver="ns1.domain.com" IsSuccess="true" />
+	</CommandResponse></ApiResponse>`
+	updated, err := client.NSUpdate("domain", "com", "ns1.domain.com", "192.0.2.1", "192.0.2.2")
+	if err != nil {
+		t.Fatalf("NSUpdate returned error: %v", err)
+	}
+	if want := (&DomainNSUpdateResult{"domain.com", "ns1.domain.com", true}); !reflect.DeepEqual(updated, want) {
+		t.Errorf("NSUpdate returned %+v, want %+v", updated, want)
+	}
+
+	correctParams = nsParams("namecheap.domains.ns.delete")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.ns.delete">
+		<DomainNSDeleteResult Domain="domain.com" Nameserver="ns1.domain.com" IsSuccess="true" />
+	</CommandResponse></ApiResponse>`
+	deleted, err := client.NSDelete("domain", "com", "ns1.domain.com")
+	if err != nil {
+		t.Fatalf("NSDelete returned error: %v", err)
+	}
+	if want := (&DomainNSDeleteResult{"domain.com", "ns1.domain.com", true}); !reflect.DeepEqual(deleted, want) {
+		t.Errorf("NSDelete returned %+v, want %+v", deleted, want)
+	}
+
+	if _, err := client.NSCreate("domain", "com", "ns1.domain.com", "not-an-ip"); err == nil {
+		t.Error("Expected error for an invalid IP")
+	}
+	if _, err := client.NSUpdate("domain", "com", "ns1.domain.com", "192.0.2.1", ""); err == nil {
+		t.Error("Expected error for an invalid new IP")
+	}
+}
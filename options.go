@@ -0,0 +1,96 @@
+package namecheap
+
+import (
+	"net/http"
+	"time"
+)
+
+// ClientOption configures a Client in NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the *http.Client used for API requests. A nil
+// httpClient restores http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
+		client.HttpClient = httpClient
+	}
+}
+
+// WithBaseURL points the client at a different API endpoint.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(client *Client) {
+		client.BaseURL = baseURL
+	}
+}
+
+// WithSandbox points the client at the Namecheap sandbox API.
+func WithSandbox() ClientOption {
+	return WithBaseURL(sandboxBaseURL)
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(client *Client) {
+		client.UserAgent = userAgent
+	}
+}
+
+// WithClientIP sets the ClientIp parameter sent with every request.
+func WithClientIP(ip string) ClientOption {
+	return func(client *Client) {
+		client.ClientIP = ip
+	}
+}
+
+// WithIPResolver discovers the ClientIp parameter with resolver.
+func WithIPResolver(resolver IPResolver) ClientOption {
+	return func(client *Client) {
+		client.IPResolver = resolver
+	}
+}
+
+// WithTimeout limits the time of each HTTP request, including reading the
+// response. It applies to a copy of the HTTP client configured so far, so
+// a shared client such as http.DefaultClient is left untouched.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		base := client.HttpClient
+		if base == nil {
+			base = http.DefaultClient
+		}
+		httpClient := *base
+		httpClient.Timeout = timeout
+		client.HttpClient = &httpClient
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the client.
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.RetryPolicy = policy
+	}
+}
+
+// WithRateLimiter sets the rate limiter of the client.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(client *Client) {
+		client.RateLimiter = limiter
+	}
+}
+
+// WithMiddleware appends middleware to the client.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.Middleware = append(client.Middleware, middleware...)
+	}
+}
+
+// WithDomainParser sets the parser splitting full domain names.
+func WithDomainParser(parser *DomainParser) ClientOption {
+	return func(client *Client) {
+		client.DomainParser = parser
+	}
+}
@@ -0,0 +1,189 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// maxPageSize is the largest page size supported by the Namecheap API.
+const maxPageSize = 100
+
+// allDomainsWorkers is how many pages AllDomains fetches at once.
+const allDomainsWorkers = 4
+
+// Domains returns an iterator over every domain matching query, ignoring its
+// Page and PageSize; a zero query matches every domain in the account. Pages
+// are fetched lazily as the iteration reaches them, so breaking out of the
+// loop early saves the remaining calls. If a page cannot be fetched the error is
+// yielded and the iteration stops. With StrictWarnings, a page whose response
+// carries warnings yields its domains and then its ApiWarnings, with a zero
+// domain, and the iteration goes on unless the loop stops.
+//
+//	for domain, err := range client.Domains(ctx, namecheap.DomainsQuery{}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (client *Client) Domains(ctx context.Context, query DomainsQuery) iter.Seq2[DomainGetListResult, error] {
+	return paginate(func(page int) ([]DomainGetListResult, *Paging, error) {
+		return client.DomainsGetListQueryContext(ctx, query.page(page))
+	})
+}
+
+// EachDomain calls fn for every domain matching query, one page at a time.
+// It stops at the first error, either from fetching a page or returned by fn.
+// With StrictWarnings, the warnings of every page are returned once every
+// domain has been passed to fn.
+func (client *Client) EachDomain(ctx context.Context, query DomainsQuery, fn func(DomainGetListResult) error) error {
+	var warnings ApiWarnings
+	for domain, err := range client.Domains(ctx, query) {
+		if failed(err) {
+			return err
+		}
+		if err != nil {
+			warnings = appendWarnings(warnings, err)
+			continue
+		}
+		if err := fn(domain); err != nil {
+			return err
+		}
+	}
+	return warningsErr(warnings)
+}
+
+// AllDomains returns every domain matching query, ignoring its Page and
+// PageSize. Once the first page has told it how many there are, it fetches
+// the remaining pages concurrently. Each page is a separate call, so they all wait for the client's
+// RateLimiter and are retried according to its RetryPolicy. With
+// StrictWarnings, the domains are returned along with the warnings of every
+// page.
+func (client *Client) AllDomains(ctx context.Context, query DomainsQuery) ([]DomainGetListResult, error) {
+	first, paging, err := client.DomainsGetListQueryContext(ctx, query.page(1))
+	if failed(err) {
+		return nil, err
+	}
+	warnings := appendWarnings(nil, err)
+	pages := pageCount(paging)
+	if pages <= 1 {
+		return first, warningsErr(warnings)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]DomainGetListResult, pages)
+	results[0] = first
+	next := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		mu       sync.Mutex
+	)
+	for i := 0; i < min(allDomainsWorkers, pages-1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range next {
+				domains, _, err := client.DomainsGetListQueryContext(ctx, query.page(page))
+				if failed(err) {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				if err != nil {
+					mu.Lock()
+					warnings = appendWarnings(warnings, err)
+					mu.Unlock()
+				}
+				results[page-1] = domains
+			}
+		}()
+	}
+feed:
+	for page := 2; page <= pages; page++ {
+		select {
+		case next <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	all := make([]DomainGetListResult, 0, paging.TotalItems)
+	for _, domains := range results {
+		all = append(all, domains...)
+	}
+	return all, warningsErr(warnings)
+}
+
+// page returns query for the given page of the largest size.
+func (query DomainsQuery) page(page int) DomainsQuery {
+	query.Page = page
+	query.PageSize = maxPageSize
+	return query
+}
+
+// paginate returns an iterator over the items of the pages returned by
+// fetch, fetching them lazily from page 1 until the last one or an error.
+// The ApiWarnings of a page are yielded after its items.
+func paginate[T any](fetch func(page int) ([]T, *Paging, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for page := 1; ; page++ {
+			items, paging, err := fetch(page)
+			if failed(err) {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if err != nil && !yield(zero, err) {
+				return
+			}
+			if len(items) == 0 || page >= pageCount(paging) {
+				return
+			}
+		}
+	}
+}
+
+// appendWarnings appends the ApiWarnings in err, if any, to warnings.
+func appendWarnings(warnings ApiWarnings, err error) ApiWarnings {
+	var w ApiWarnings
+	if errors.As(err, &w) {
+		warnings = append(warnings, w...)
+	}
+	return warnings
+}
+
+// warningsErr returns warnings as an error, or nil if there are none.
+func warningsErr(warnings ApiWarnings) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+// pageCount returns the number of pages described by paging.
+func pageCount(paging *Paging) int {
+	if paging == nil || paging.PageSize <= 0 {
+		return 1
+	}
+	return (paging.TotalItems + paging.PageSize - 1) / paging.PageSize
+}
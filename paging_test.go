@@ -0,0 +1,183 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// serveDomainPages serves total domains named example<N>.com, in pages of
+// the requested size, failing requests for page failPage and adding a
+// warning to page warnPage.
+func serveDomainPages(t *testing.T, total, failPage, warnPage int, calls *int32) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		page, _ := strconv.Atoi(r.FormValue("Page"))
+		pageSize, _ := strconv.Atoi(r.FormValue("PageSize"))
+		if pageSize != 100 {
+			t.Errorf("PageSize = %v, want 100", pageSize)
+		}
+		if page == failPage {
+			fmt.Fprint(w, `<ApiResponse Status="ERROR"><Errors><Error Number="5050900">Unknown error</Error></Errors></ApiResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<ApiResponse Status="OK">`)
+		if page == warnPage {
+			fmt.Fprint(w, `<Warnings><Warning Number="3050900">Unknown response from provider</Warning></Warnings>`)
+		}
+		fmt.Fprint(w, `<CommandResponse Type="namecheap.domains.getList"><DomainGetListResult>`)
+		for i := (page - 1) * pageSize; i < page*pageSize && i < total; i++ {
+			fmt.Fprintf(w, `<Domain ID="%d" Name="example%d.com" />`, i, i)
+		}
+		fmt.Fprintf(w, `</DomainGetListResult><Paging><TotalItems>%d</TotalItems><CurrentPage>%d</CurrentPage><PageSize>%d</PageSize></Paging></CommandResponse></ApiResponse>`,
+			total, page, pageSize)
+	})
+}
+
+func TestDomains(t *testing.T) {
+	setup()
+	defer teardown()
+	var calls int32
+	serveDomainPages(t, 250, 0, 0, &calls)
+
+	i := 0
+	for domain, err := range client.Domains(context.Background(), DomainsQuery{}) {
+		if err != nil {
+			t.Fatalf("Domains yielded error: %v", err)
+		}
+		if want := fmt.Sprintf("example%d.com", i); domain.Name != want {
+			t.Errorf("Domain %d = %v, want %v", i, domain.Name, want)
+		}
+		i++
+	}
+	if i != 250 || atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("Domains yielded %d domains in %d calls, want 250 in 3", i, atomic.LoadInt32(&calls))
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	for range client.Domains(context.Background(), DomainsQuery{}) {
+		break
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("Breaking after the first domain made %d calls, want 1", atomic.LoadInt32(&calls))
+	}
+}
+
+func TestEachDomainError(t *testing.T) {
+	setup()
+	defer teardown()
+	var calls int32
+	serveDomainPages(t, 250, 2, 0, &calls)
+
+	n := 0
+	err := client.EachDomain(context.Background(), DomainsQuery{}, func(DomainGetListResult) error {
+		n++
+		return nil
+	})
+	var apiErrs ApiErrors
+	if !errors.As(err, &apiErrs) || apiErrs[0].Number != 5050900 {
+		t.Errorf("EachDomain returned %v, want the error for page 2", err)
+	}
+	if n != 100 {
+		t.Errorf("EachDomain visited %d domains before the error, want 100", n)
+	}
+
+	stop := errors.New("stop")
+	if err := client.EachDomain(context.Background(), DomainsQuery{}, func(DomainGetListResult) error { return stop }); err != stop {
+		t.Errorf("EachDomain returned %v, want the callback's error", err)
+	}
+}
+
+func TestAllDomains(t *testing.T) {
+	setup()
+	defer teardown()
+	var calls int32
+	serveDomainPages(t, 1050, 0, 0, &calls)
+
+	domains, err := client.AllDomains(context.Background(), DomainsQuery{})
+	if err != nil {
+		t.Fatalf("AllDomains returned error: %v", err)
+	}
+	if len(domains) != 1050 || atomic.LoadInt32(&calls) != 11 {
+		t.Fatalf("AllDomains returned %d domains in %d calls, want 1050 in 11", len(domains), atomic.LoadInt32(&calls))
+	}
+	for i, domain := range domains {
+		if domain.ID != i {
+			t.Fatalf("Domain %d has ID %d, want pages in order", i, domain.ID)
+		}
+	}
+}
+
+func TestStrictWarningsPages(t *testing.T) {
+	setup()
+	defer teardown()
+	client.StrictWarnings = true
+	var calls int32
+	serveDomainPages(t, 250, 0, 2, &calls)
+
+	n, warned := 0, 0
+	for _, err := range client.Domains(context.Background(), DomainsQuery{}) {
+		var warnings ApiWarnings
+		switch {
+		case errors.As(err, &warnings):
+			warned++
+		case err != nil:
+			t.Fatalf("Domains yielded error: %v", err)
+		default:
+			n++
+		}
+	}
+	if n != 250 || warned != 1 {
+		t.Errorf("Domains yielded %d domains and %d warnings, want 250 and 1", n, warned)
+	}
+
+	n = 0
+	err := client.EachDomain(context.Background(), DomainsQuery{}, func(DomainGetListResult) error {
+		n++
+		return nil
+	})
+	var warnings ApiWarnings
+	if !errors.As(err, &warnings) || n != 250 {
+		t.Errorf("EachDomain visited %d domains and returned %v, want 250 and the warnings", n, err)
+	}
+
+	domains, err := client.AllDomains(context.Background(), DomainsQuery{})
+	if !errors.As(err, &warnings) || len(warnings) != 1 || warnings[0].Number != 3050900 {
+		t.Errorf("AllDomains returned %v, want the warnings of page 2", err)
+	}
+	if len(domains) != 250 {
+		t.Errorf("AllDomains returned %d domains, want 250", len(domains))
+	}
+}
+
+func TestAllDomainsError(t *testing.T) {
+	setup()
+	defer teardown()
+	var calls int32
+	serveDomainPages(t, 1050, 7, 0, &calls)
+
+	if _, err := client.AllDomains(context.Background(), DomainsQuery{}); err == nil {
+		t.Error("Expected error from AllDomains")
+	}
+}
+
+func TestAllDomainsRateLimited(t *testing.T) {
+	setup()
+	defer teardown()
+	var calls int32
+	serveDomainPages(t, 1050, 0, 0, &calls)
+
+	client.RateLimiter = NewRateLimiter(RateWindow{Limit: 5, Period: time.Hour})
+	client.RateLimiter.FailFast = true
+	if _, err := client.AllDomains(context.Background(), DomainsQuery{}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("AllDomains returned %v, want ErrRateLimited", err)
+	}
+	if atomic.LoadInt32(&calls) > 5 {
+		t.Errorf("AllDomains made %d calls, want at most the 5 allowed", atomic.LoadInt32(&calls))
+	}
+}
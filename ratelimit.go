@@ -0,0 +1,190 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned by a fail-fast RateLimiter when a call would
+// exceed one of its windows.
+var ErrRateLimited = errors.New("namecheap: rate limit exceeded")
+
+// RateWindow is a quota of Limit calls per Period.
+type RateWindow struct {
+	Limit  int
+	Period time.Duration
+}
+
+// DefaultRateWindows are the per-account quotas enforced by Namecheap.
+var DefaultRateWindows = []RateWindow{
+	{Limit: 20, Period: time.Minute},
+	{Limit: 700, Period: time.Hour},
+	{Limit: 8000, Period: 24 * time.Hour},
+}
+
+// RateBudget is the remaining budget of a single RateWindow.
+type RateBudget struct {
+	Window    RateWindow
+	Remaining int
+	// Full is the time until the window's budget is completely refilled.
+	Full time.Duration
+}
+
+// RateLimiter is a token bucket limiter enforcing several windows at once.
+// A call is only allowed once every window has a token available.
+// It is safe for concurrent use and is meant to be shared by every
+// goroutine using the same account. The zero value enforces
+// DefaultRateWindows, so &RateLimiter{FailFast: true} is ready to use.
+type RateLimiter struct {
+	// FailFast makes the client return ErrRateLimited instead of waiting
+	// for budget to become available.
+	FailFast bool
+
+	mu      sync.Mutex
+	buckets []*tokenBucket
+	now     func() time.Time
+}
+
+type tokenBucket struct {
+	window RateWindow
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter for the given windows, each starting with
+// its full budget. Without windows, DefaultRateWindows are used.
+func NewRateLimiter(windows ...RateWindow) *RateLimiter {
+	if len(windows) == 0 {
+		windows = DefaultRateWindows
+	}
+
+	limiter := &RateLimiter{now: time.Now}
+	limiter.addWindows(windows)
+	return limiter
+}
+
+// addWindows adds a full bucket per window.
+func (limiter *RateLimiter) addWindows(windows []RateWindow) {
+	start := limiter.now()
+	for _, w := range windows {
+		limiter.buckets = append(limiter.buckets, &tokenBucket{
+			window: w,
+			tokens: float64(w.Limit),
+			last:   start,
+		})
+	}
+}
+
+// lazyInit sets up a zero RateLimiter on first use. limiter.mu must be held.
+func (limiter *RateLimiter) lazyInit() {
+	if limiter.now == nil {
+		limiter.now = time.Now
+	}
+	if limiter.buckets == nil {
+		limiter.addWindows(DefaultRateWindows)
+	}
+}
+
+// Allow takes a token from every window if all of them have one available.
+// It never blocks.
+func (limiter *RateLimiter) Allow() bool {
+	_, ok := limiter.take()
+	return ok
+}
+
+// Wait blocks until a token is available in every window and takes it, or
+// returns ctx.Err() if ctx is done first.
+func (limiter *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		wait, ok := limiter.take()
+		if ok {
+			return nil
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Remaining reports the budget left in each window.
+func (limiter *RateLimiter) Remaining() []RateBudget {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.lazyInit()
+
+	now := limiter.now()
+	budgets := make([]RateBudget, 0, len(limiter.buckets))
+	for _, b := range limiter.buckets {
+		b.refill(now)
+		budgets = append(budgets, RateBudget{
+			Window:    b.window,
+			Remaining: int(math.Floor(b.tokens)),
+			Full:      b.timeUntil(float64(b.window.Limit)),
+		})
+	}
+	return budgets
+}
+
+// take consumes a token from every bucket, or reports how long to wait
+// before trying again.
+func (limiter *RateLimiter) take() (time.Duration, bool) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.lazyInit()
+
+	now := limiter.now()
+	var wait time.Duration
+	for _, b := range limiter.buckets {
+		b.refill(now)
+		if d := b.timeUntil(1); d > wait {
+			wait = d
+		}
+	}
+	if wait > 0 {
+		return wait, false
+	}
+
+	for _, b := range limiter.buckets {
+		b.tokens--
+	}
+	return 0, true
+}
+
+func (limiter *RateLimiter) acquire(ctx context.Context) error {
+	if limiter == nil {
+		return nil
+	}
+	if limiter.FailFast {
+		if !limiter.Allow() {
+			return ErrRateLimited
+		}
+		return nil
+	}
+	return limiter.Wait(ctx)
+}
+
+func (b *tokenBucket) rate() float64 {
+	return float64(b.window.Limit) / float64(b.window.Period)
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(b.window.Limit), b.tokens+float64(elapsed)*b.rate())
+		b.last = now
+	}
+}
+
+// timeUntil returns how long until the bucket holds n tokens.
+func (b *tokenBucket) timeUntil(n float64) time.Duration {
+	if b.tokens >= n {
+		return 0
+	}
+	if b.window.Limit <= 0 {
+		// A window without budget never refills.
+		return math.MaxInt64
+	}
+	return time.Duration(math.Ceil((n - b.tokens) / b.rate()))
+}
@@ -0,0 +1,104 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWindows(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(
+		RateWindow{Limit: 2, Period: time.Minute},
+		RateWindow{Limit: 3, Period: time.Hour},
+	)
+	limiter.now = func() time.Time { return now }
+	for _, b := range limiter.buckets {
+		b.last = now
+	}
+
+	if !limiter.Allow() || !limiter.Allow() {
+		t.Fatal("Expected the first two calls to be allowed")
+	}
+	if limiter.Allow() {
+		t.Error("Expected the per-minute window to be exhausted")
+	}
+
+	budgets := limiter.Remaining()
+	if budgets[0].Remaining != 0 || budgets[1].Remaining != 1 {
+		t.Errorf("Remaining returned %+v", budgets)
+	}
+	if budgets[0].Full != time.Minute {
+		t.Errorf("Expected the minute window to refill in a minute, got %v", budgets[0].Full)
+	}
+
+	now = now.Add(time.Minute)
+	if !limiter.Allow() {
+		t.Error("Expected a call to be allowed after the minute window refilled")
+	}
+	if limiter.Allow() {
+		t.Error("Expected the per-hour window to be exhausted")
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := NewRateLimiter(RateWindow{Limit: 1, Period: 20 * time.Millisecond})
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Expected Wait to block for a refill, returned after %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRateLimiterZeroValue(t *testing.T) {
+	limiter := &RateLimiter{FailFast: true}
+	if !limiter.Allow() {
+		t.Fatal("Expected the first call to be allowed")
+	}
+	budgets := limiter.Remaining()
+	if len(budgets) != len(DefaultRateWindows) || budgets[0].Remaining != DefaultRateWindows[0].Limit-1 {
+		t.Errorf("Remaining returned %+v, want the default windows less one call", budgets)
+	}
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Errorf("Wait returned error: %v", err)
+	}
+}
+
+func TestClientRateLimiterFailFast(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, okResponseXML)
+	})
+
+	client.RateLimiter = NewRateLimiter(RateWindow{Limit: 1, Period: time.Hour})
+	client.RateLimiter.FailFast = true
+
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+	if _, err := client.DomainGetInfo("example.com"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single request to reach the API, got %d", calls)
+	}
+}
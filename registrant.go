@@ -70,9 +70,9 @@ type Registrant struct {
 	AuxBillingEmailAddress string `xml:"AuxBilling>EmailAddress"`
 }
 
-// newRegistrant return a new registrant where all the required fields are the same.
+// NewRegistrant returns a new registrant where all the required fields are the same.
 // Feel free to change them as needed
-func newRegistrant(
+func NewRegistrant(
 	firstName, lastName,
 	addr1, addr2,
 	city, state, postalCode, country,
@@ -129,26 +129,36 @@ func (reg *Registrant) addValues(u url.Values) error {
 		return errors.New("nil value passed as url.Values")
 	}
 
-	val := reflect.ValueOf(*reg)
+	return stringFields(*reg, func(fieldName, field string) error {
+		if field == "" {
+			if strings.Contains(fieldName, "ddress2") {
+				return nil
+			}
+
+			return fmt.Errorf("Field %s cannot be empty", fieldName)
+		}
+
+		u.Set(fieldName, field)
+		return nil
+	})
+}
+
+// stringFields calls fn with the name and value of each field of the struct
+// v, which must all be strings, stopping at the first error.
+func stringFields(v interface{}, fn func(name, value string) error) error {
+	val := reflect.ValueOf(v)
 	t := val.Type()
 	for i := 0; i < val.NumField(); i++ {
 		fieldName := t.Field(i).Name
-		field := val.Field(i).String()
 		if ty := val.Field(i).Kind(); ty != reflect.String {
 			return fmt.Errorf(
-				"Registrant cannot have types that aren't string; %s is type %s",
-				fieldName, ty,
+				"%s cannot have types that aren't string; %s is type %s",
+				t.Name(), fieldName, ty,
 			)
 		}
-		if field == "" {
-			if strings.Contains(fieldName, "ddress2") {
-				continue
-			}
-
-			return fmt.Errorf("Field %s cannot be empty", fieldName)
+		if err := fn(fieldName, val.Field(i).String()); err != nil {
+			return err
 		}
-
-		u.Set(fieldName, fmt.Sprintf("%v", field))
 	}
 
 	return nil
@@ -0,0 +1,191 @@
+package namecheap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ApiResponse is the envelope shared by every API response. The
+// CommandResponse element is decoded separately, into the result type of
+// the command that was called.
+type ApiResponse struct {
+	Status string
+	Errors ApiErrors
+
+	ResponseMeta
+}
+
+type Paging struct {
+	TotalItems  int `xml:"TotalItems"`
+	CurrentPage int `xml:"CurrentPage"`
+	PageSize    int `xml:"PageSize"`
+}
+
+// decodeResponse decodes the envelope of body in a single pass, unmarshalling
+// the CommandResponse element into result (if not nil) and skipping any
+// other element without building it.
+func decodeResponse(body []byte, result interface{}) (*ApiResponse, error) {
+	resp := new(ApiResponse)
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+
+	inRoot := false
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := tok.(xml.EndElement); ok {
+			// Children are consumed whole, so this closes the root.
+			break
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if !inRoot {
+			inRoot = true
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "Status" {
+					resp.Status = attr.Value
+				}
+			}
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Errors":
+			var errs struct {
+				Errors ApiErrors `xml:"Error"`
+			}
+			err = decoder.DecodeElement(&errs, &start)
+			resp.Errors = errs.Errors
+		case "Warnings":
+			var warnings struct {
+				Warnings ApiWarnings `xml:"Warning"`
+			}
+			err = decoder.DecodeElement(&warnings, &start)
+			resp.Warnings = warnings.Warnings
+		case "RequestedCommand":
+			err = decoder.DecodeElement(&resp.Command, &start)
+		case "Server":
+			err = decoder.DecodeElement(&resp.Server, &start)
+		case "GMTTimeDifference":
+			err = decoder.DecodeElement(&resp.GMTTimeDifference, &start)
+		case "ExecutionTime":
+			var seconds float64
+			if err = decoder.DecodeElement(&seconds, &start); err == nil {
+				resp.ExecutionTime = time.Duration(seconds * float64(time.Second))
+			}
+		case "CommandResponse":
+			if result != nil {
+				err = decoder.DecodeElement(result, &start)
+			} else {
+				err = decoder.Skip()
+			}
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.Status == "" {
+		return nil, errors.New("failed to parse xml from api")
+	}
+	return resp, nil
+}
+
+// attrFieldsCache maps a struct type to the field indexes of its
+// `xml:"Name,attr"` tags, keyed by attribute name.
+var attrFieldsCache sync.Map
+
+func attrFields(t reflect.Type) map[string]int {
+	if fields, ok := attrFieldsCache.Load(t); ok {
+		return fields.(map[string]int)
+	}
+
+	fields := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("xml")
+		if name, ok := strings.CutSuffix(tag, ",attr"); ok {
+			fields[name] = i
+		}
+	}
+	attrFieldsCache.Store(t, fields)
+	return fields
+}
+
+// unmarshalAttrs sets the attribute fields of the struct v points to from
+// start, the way encoding/xml would, then skips the element's content.
+// Rows of large listings implement UnmarshalXML with it, which avoids the
+// copies encoding/xml makes of every attribute value.
+func unmarshalAttrs(d *xml.Decoder, start xml.StartElement, v interface{}) error {
+	val := reflect.ValueOf(v).Elem()
+	fields := attrFields(val.Type())
+
+	for _, attr := range start.Attr {
+		i, ok := fields[attr.Name.Local]
+		if !ok {
+			continue
+		}
+		field := val.Field(i)
+		if u, ok := field.Addr().Interface().(xml.UnmarshalerAttr); ok {
+			if err := u.UnmarshalXMLAttr(attr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		s := strings.TrimSpace(attr.Value)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(attr.Value)
+		case reflect.Bool:
+			if s == "" {
+				field.SetBool(false)
+				continue
+			}
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if s == "" {
+				field.SetInt(0)
+				continue
+			}
+			n, err := strconv.ParseInt(s, 10, field.Type().Bits())
+			if err != nil {
+				return err
+			}
+			field.SetInt(n)
+		case reflect.Float32, reflect.Float64:
+			if s == "" {
+				field.SetFloat(0)
+				continue
+			}
+			f, err := strconv.ParseFloat(s, field.Type().Bits())
+			if err != nil {
+				return err
+			}
+			field.SetFloat(f)
+		default:
+			return fmt.Errorf("cannot unmarshal attribute %s into %s", attr.Name.Local, field.Type())
+		}
+	}
+	return d.Skip()
+}
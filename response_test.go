@@ -0,0 +1,166 @@
+package namecheap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="ERROR" xmlns="http://api.namecheap.com/xml.response">
+  <Errors>
+    <Error Number="2019166">Domain not found</Error>
+  </Errors>
+  <Warnings />
+  <RequestedCommand>namecheap.domains.getinfo</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.getInfo">
+    <DomainGetInfoResult DomainName="example.com" />
+  </CommandResponse>
+</ApiResponse>`)
+
+	var result struct {
+		DomainInfo *DomainInfo `xml:"DomainGetInfoResult"`
+	}
+	resp, err := decodeResponse(body, &result)
+	if err != nil {
+		t.Fatalf("decodeResponse returned error: %v", err)
+	}
+	if resp.Status != "ERROR" || resp.Command != "namecheap.domains.getinfo" {
+		t.Errorf("decodeResponse returned %+v", resp)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Number != 2019166 {
+		t.Errorf("decodeResponse returned errors %+v", resp.Errors)
+	}
+	if result.DomainInfo == nil || result.DomainInfo.Name != "example.com" {
+		t.Errorf("decodeResponse decoded result %+v", result.DomainInfo)
+	}
+
+	if _, err := decodeResponse([]byte("<invalid />"), nil); err == nil {
+		t.Error("Expected error for a document without Status")
+	}
+}
+
+// legacyApiResponse is the single response struct every command used to be
+// decoded into, kept to benchmark against per-command decoding. Its rows are
+// decoded by reflection, as they were before they implemented UnmarshalXML.
+type legacyApiResponse struct {
+	Status             string                    `xml:"Status,attr"`
+	Command            string                    `xml:"RequestedCommand"`
+	TLDList            []TLDListResult           `xml:"CommandResponse>Tlds>Tld"`
+	AddressGetList     []AddressGetListResult    `xml:"CommandResponse>AddressGetListResult>List"`
+	AddressGetInfo     *AddressGetInfoResult     `xml:"CommandResponse>GetAddressInfoResult"`
+	Domains            []legacyDomain            `xml:"CommandResponse>DomainGetListResult>Domain"`
+	DomainInfo         *DomainInfo               `xml:"CommandResponse>DomainGetInfoResult"`
+	DomainDNSHosts     *legacyDNSGetHostsResult  `xml:"CommandResponse>DomainDNSGetHostsResult"`
+	DomainDNSSetHosts  *DomainDNSSetHostsResult  `xml:"CommandResponse>DomainDNSSetHostsResult"`
+	DomainCreate       *DomainCreateResult       `xml:"CommandResponse>DomainCreateResult"`
+	DomainRenew        *DomainRenewResult        `xml:"CommandResponse>DomainRenewResult"`
+	DomainsCheck       []DomainCheckResult       `xml:"CommandResponse>DomainCheckResult"`
+	DomainNSInfo       *DomainNSInfoResult       `xml:"CommandResponse>DomainNSInfoResult"`
+	DomainDNSSetCustom *DomainDNSSetCustomResult `xml:"CommandResponse>DomainDNSSetCustomResult"`
+	DomainContacts     *DomainGetContactsResult  `xml:"CommandResponse>DomainContactsResult"`
+	UsersGetPricing    []UsersGetPricingResult   `xml:"CommandResponse>UserGetPricingResult>ProductType"`
+	UsersGetBalances   []UsersGetBalancesResult  `xml:"CommandResponse>UserGetBalancesResult"`
+	WhoisguardList     []WhoisguardGetListResult `xml:"CommandResponse>WhoisguardGetListResult>Whoisguard"`
+	WhoisguardEnable   whoisguardEnableResult    `xml:"CommandResponse>WhoisguardEnableResult"`
+	WhoisguardDisable  whoisguardDisableResult   `xml:"CommandResponse>WhoisguardDisableResult"`
+	WhoisguardRenew    *WhoisguardRenewResult    `xml:"CommandResponse>WhoisguardRenewResult"`
+	Paging             *Paging                   `xml:"CommandResponse>Paging"`
+	Errors             ApiErrors                 `xml:"Errors>Error"`
+}
+
+type legacyDomain DomainGetListResult
+
+type legacyDNSHost DomainDNSHost
+
+type legacyDNSGetHostsResult struct {
+	Domain        string          `xml:"Domain,attr"`
+	IsUsingOurDNS bool            `xml:"IsUsingOurDNS,attr"`
+	Hosts         []legacyDNSHost `xml:"host"`
+}
+
+func largeResponse(command, result string) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+  <Errors />
+  <Warnings />
+  <RequestedCommand>%s</RequestedCommand>
+  <CommandResponse Type="%s">%s</CommandResponse>
+  <Server>WEB1-SANDBOX1</Server>
+  <GMTTimeDifference>--5:00</GMTTimeDifference>
+  <ExecutionTime>0.009</ExecutionTime>
+</ApiResponse>`, command, command, result))
+}
+
+func largeDomainsGetList() []byte {
+	var b strings.Builder
+	b.WriteString("<DomainGetListResult>")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, `<Domain ID="%d" Name="example%d.com" User="anUser" Created="11/04/2014" Expires="11/04/2015" IsExpired="false" IsLocked="false" AutoRenew="false" WhoisGuard="ENABLED" />`, i, i)
+	}
+	b.WriteString("</DomainGetListResult><Paging><TotalItems>1000</TotalItems><CurrentPage>1</CurrentPage><PageSize>100</PageSize></Paging>")
+	return largeResponse(domainsGetList, b.String())
+}
+
+func largeDomainsDNSGetHosts() []byte {
+	var b strings.Builder
+	b.WriteString(`<DomainDNSGetHostsResult Domain="domain.com" IsUsingOurDNS="true">`)
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, `<host HostId="%d" Name="host%d" Type="A" Address="1.2.3.4" MXPref="10" TTL="1800" />`, i, i)
+	}
+	b.WriteString("</DomainDNSGetHostsResult>")
+	return largeResponse(domainsDNSGetHosts, b.String())
+}
+
+func BenchmarkDecodeDomainsGetList(b *testing.B) {
+	body := largeDomainsGetList()
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			resp := new(legacyApiResponse)
+			if err := xml.Unmarshal(body, resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("per-command", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var result struct {
+				Domains []DomainGetListResult `xml:"DomainGetListResult>Domain"`
+				Paging  *Paging               `xml:"Paging"`
+			}
+			if _, err := decodeResponse(body, &result); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDecodeDomainsDNSGetHosts(b *testing.B) {
+	body := largeDomainsDNSGetHosts()
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			resp := new(legacyApiResponse)
+			if err := xml.Unmarshal(body, resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("per-command", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var result struct {
+				DomainDNSHosts *DomainDNSGetHostsResult `xml:"DomainDNSGetHostsResult"`
+			}
+			if _, err := decodeResponse(body, &result); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
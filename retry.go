@@ -0,0 +1,171 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// idempotentCommands are the commands which may be re-sent after a failure:
+// the ones reading data, and the ones whose effect does not change when
+// applied twice. Any other command, and in particular those which charge
+// the account such as domains.create or ssl.create, is never re-sent unless
+// the failed attempt provably did not reach the API, because a lost
+// response does not mean the command did not take effect. This includes
+// ns.create, ns.update and transfer.updateStatus, which fail or act on
+// changed state when sent a second time.
+var idempotentCommands = map[string]bool{
+	domainsDNSSetHosts:      true,
+	domainsDNSSetCustom:     true,
+	domainsSetContacts:      true,
+	domainsSetRegistrarLock: true,
+	nsDelete:                true,
+	whoisguardEnable:        true,
+	whoisguardDisable:       true,
+}
+
+// isIdempotent reports whether command may be re-sent. Besides
+// idempotentCommands, every command named like a read, such as
+// namecheap.domains.getInfo or namecheap.domains.check, is idempotent,
+// including the ones this package does not wrap.
+func isIdempotent(command string) bool {
+	if idempotentCommands[command] {
+		return true
+	}
+	action := command[strings.LastIndexByte(command, '.')+1:]
+	return strings.HasPrefix(action, "get") || action == "check"
+}
+
+// transientErrorNumbers are Namecheap error numbers which signal a temporary
+// failure on their side rather than a problem with the request.
+var transientErrorNumbers = map[int]bool{
+	500000:  true, // Too many requests
+	3050900: true, // Unknown response from provider
+	5050900: true, // Unhandled exception
+}
+
+// RetryPolicy describes how failed calls are retried. The delay before
+// attempt n+1 is InitialBackoff doubled n-1 times, capped at MaxBackoff,
+// and reduced by a random fraction of up to Jitter.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. Zero leaves it uncapped.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (between 0 and 1) of each delay that is
+	// randomised, so concurrent clients do not retry in lockstep.
+	Jitter float64
+
+	// Retryable reports whether a failed call may be attempted again.
+	// Defaults to IsRetryable.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns a policy making up to 4 attempts, backing off
+// from half a second up to 10 seconds with full jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Jitter:         1,
+	}
+}
+
+// IsRetryable is the default retry classifier. Transport errors, 429 and 5xx
+// status codes and transient Namecheap errors are retryable; cancellation,
+// other status codes and all remaining API errors are not.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	var apiErrs ApiErrors
+	if errors.As(err, &apiErrs) {
+		for _, apiErr := range apiErrs {
+			if !transientErrorNumbers[apiErr.Number] {
+				return false
+			}
+		}
+		return len(apiErrs) > 0
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// notSent reports whether err proves the request never reached the API,
+// which is the only case where a command that is not idempotent may be
+// re-sent.
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (policy *RetryPolicy) shouldRetry(command string, attempt int, err error) bool {
+	if policy == nil || attempt >= policy.MaxAttempts {
+		return false
+	}
+	if !isIdempotent(command) && !notSent(err) {
+		return false
+	}
+
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	return retryable(err)
+}
+
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	limit := policy.MaxBackoff
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	delay := policy.InitialBackoff
+	for i := 1; i < attempt && delay < limit; i++ {
+		if delay > limit/2 {
+			delay = limit
+			break
+		}
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+	if policy.Jitter > 0 {
+		delay -= time.Duration(policy.Jitter * rand.Float64() * float64(delay))
+	}
+	return delay
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
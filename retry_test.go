@@ -0,0 +1,193 @@
+package namecheap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	}
+}
+
+func TestRetryIdempotentCommand(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, okResponseXML)
+	})
+
+	if _, err := client.DomainGetInfo("example.com"); err != nil {
+		t.Errorf("DomainGetInfo returned error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := client.DomainGetInfo("example.com")
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected HTTPStatusError 502, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryNeverResendsBillingCommand(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	client.NewRegistrant(
+		"r", "m",
+		"10 Park Ave.", "",
+		"NY", "New York", "10001", "US",
+		"+1.9125357070", "joe.dirt1@gmail.com",
+	)
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.DomainCreate("example.com", 1); err == nil {
+		t.Error("Expected error from DomainCreate")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt for a billing command, got %d", calls)
+	}
+}
+
+func TestRetryNeverResendsNonIdempotentCommands(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := client.NSCreate("example", "com", "ns1.example.com", "10.0.0.1"); err == nil {
+		t.Error("Expected error from NSCreate")
+	}
+	if _, err := client.TransferUpdateStatus(15, "s3cr3t"); err == nil {
+		t.Error("Expected error from TransferUpdateStatus")
+	}
+	if calls != 2 {
+		t.Errorf("Expected a single attempt per command, got %d", calls)
+	}
+}
+
+func TestRetryCallOnlyResendsReads(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if err := client.Call("namecheap.ssl.create", url.Values{"Type": {"PositiveSSL"}}, nil); err == nil {
+		t.Error("Expected error from Call")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt for an unknown command, got %d", calls)
+	}
+
+	calls = 0
+	if err := client.Call("namecheap.domains.dns.getEmailForwarding", nil, nil); err == nil {
+		t.Error("Expected error from Call")
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts for a read, got %d", calls)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&HTTPStatusError{StatusCode: 500}, true},
+		{&HTTPStatusError{StatusCode: 429}, true},
+		{&HTTPStatusError{StatusCode: 404}, false},
+		{ApiErrors{{Number: 5050900}}, true},
+		{ApiErrors{{Number: 2019166}}, false},
+		{&net.OpError{Op: "read", Err: errors.New("reset")}, true},
+		{errors.New("plain"), false},
+	}
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+
+	if !notSent(&net.OpError{Op: "dial", Err: errors.New("refused")}) {
+		t.Error("Expected dial error to count as not sent")
+	}
+	if notSent(&net.OpError{Op: "read", Err: errors.New("reset")}) {
+		t.Error("Expected read error to count as possibly sent")
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second},
+		{10, 5 * time.Second},
+	}
+	for _, c := range cases {
+		if got := policy.backoff(c.attempt); got != c.want {
+			t.Errorf("backoff(%d) = %v, want %v", c.attempt, got, c.want)
+		}
+	}
+
+	// Without MaxBackoff the delay keeps doubling until it would overflow.
+	policy.MaxBackoff = 0
+	if got, want := policy.backoff(4), 8*time.Second; got != want {
+		t.Errorf("Uncapped backoff(4) = %v, want %v", got, want)
+	}
+	if got := policy.backoff(100); got != math.MaxInt64 {
+		t.Errorf("Uncapped backoff(100) = %v, want the longest duration", got)
+	}
+}
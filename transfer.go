@@ -0,0 +1,316 @@
+package namecheap
+
+import (
+	"context"
+	"encoding/xml"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+const (
+	transferCreate       = "namecheap.domains.transfer.create"
+	transferGetStatus    = "namecheap.domains.transfer.getStatus"
+	transferUpdateStatus = "namecheap.domains.transfer.updateStatus"
+	transferGetList      = "namecheap.domains.transfer.getList"
+)
+
+// TransferStatusID identifies the status of a transfer, described by the
+// Status text returned alongside it.
+type TransferStatusID int
+
+// Statuses a transfer ends in.
+const (
+	TransferStatusCancelled TransferStatusID = -1 // Transfer cancelled
+	TransferStatusCompleted TransferStatusID = 5  // Domain transferred successfully
+)
+
+// Statuses which need action before the transfer can go on, after which it
+// is resubmitted with TransferUpdateStatus.
+const (
+	TransferStatusInvalidPromotion TransferStatusID = -4   // Promotion code is invalid
+	TransferStatusWaitingEPPCode   TransferStatusID = -22  // Waiting for EPP code
+	TransferStatusSixtyDayLock     TransferStatusID = -202 // Domain is not eligible for transfer due to 60-day lock
+)
+
+// NeedsAction reports whether the transfer waits on the customer, e.g. for
+// a corrected EPP code.
+func (id TransferStatusID) NeedsAction() bool {
+	switch id {
+	case TransferStatusInvalidPromotion, TransferStatusWaitingEPPCode, TransferStatusSixtyDayLock:
+		return true
+	}
+	return false
+}
+
+// TransferState is the stage a transfer is in, as used by the ListType of
+// 'domains.transfer.getList'.
+type TransferState string
+
+const (
+	TransferStateAll        TransferState = "ALL"
+	TransferStateInProgress TransferState = "INPROGRESS"
+	TransferStateCancelled  TransferState = "CANCELLED"
+	TransferStateCompleted  TransferState = "COMPLETED"
+)
+
+// transferStates maps each status ID defined above to the state of the
+// transfer.
+var transferStates = map[TransferStatusID]TransferState{
+	TransferStatusCancelled:        TransferStateCancelled,
+	TransferStatusCompleted:        TransferStateCompleted,
+	TransferStatusInvalidPromotion: TransferStateInProgress,
+	TransferStatusWaitingEPPCode:   TransferStateInProgress,
+	TransferStatusSixtyDayLock:     TransferStateInProgress,
+}
+
+// transferState classifies a transfer by its status ID alone. IDs missing
+// from transferStates are taken to be in progress, so a transfer is never
+// reported as finished on a guess.
+func transferState(id TransferStatusID) TransferState {
+	if state, ok := transferStates[id]; ok {
+		return state
+	}
+	return TransferStateInProgress
+}
+
+type TransferCreateResult struct {
+	DomainName    string           `xml:"DomainName,attr"`
+	Transfer      bool             `xml:"Transfer,attr"`
+	TransferID    int              `xml:"TransferID,attr"`
+	StatusID      TransferStatusID `xml:"StatusID,attr"`
+	OrderID       int              `xml:"OrderID,attr"`
+	TransactionID int              `xml:"TransactionID,attr"`
+	ChargedAmount Money            `xml:"ChargedAmount,attr"`
+}
+
+type TransferCreateOption struct {
+	PromotionCode     string
+	AddFreeWhoisguard bool
+	WGEnabled         bool
+	// IsPremiumDomain and PremiumPrice must be set to transfer a premium
+	// domain, PremiumPrice being its transfer price.
+	IsPremiumDomain bool
+	PremiumPrice    Money
+}
+
+// TransferStatus is the status of a transfer, as returned by
+// 'domains.transfer.getStatus'.
+type TransferStatus struct {
+	TransferID int              `xml:"TransferID,attr" json:"transfer_id"`
+	Status     string           `xml:"Status,attr" json:"status"`
+	StatusID   TransferStatusID `xml:"StatusID,attr" json:"status_id"`
+}
+
+// State returns the stage of the transfer, from its StatusID.
+func (status *TransferStatus) State() TransferState {
+	return transferState(status.StatusID)
+}
+
+type TransferUpdateStatusResult struct {
+	TransferID int  `xml:"TransferID,attr"`
+	Resubmit   bool `xml:"Resubmit,attr"`
+}
+
+// TransferGetListResult represents a transfer returned by
+// 'domains.transfer.getList'.
+type TransferGetListResult struct {
+	ID                int              `xml:"ID,attr"`
+	DomainName        string           `xml:"DomainName,attr"`
+	User              string           `xml:"User,attr"`
+	TransferDate      Date             `xml:"TransferDate,attr"`
+	OrderID           int              `xml:"OrderID,attr"`
+	StatusID          TransferStatusID `xml:"StatusID,attr"`
+	Status            string           `xml:"Status,attr"`
+	StatusDate        Date             `xml:"StatusDate,attr"`
+	StatusDescription string           `xml:"StatusDescription,attr"`
+}
+
+func (result *TransferGetListResult) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalAttrs(d, start, result)
+}
+
+// State returns the stage of the transfer, from its StatusID.
+func (result *TransferGetListResult) State() TransferState {
+	return transferState(result.StatusID)
+}
+
+// TransferSortBy is the order 'domains.transfer.getList' returns transfers in.
+type TransferSortBy string
+
+const (
+	TransferSortByDomainName       TransferSortBy = "DOMAINNAME"
+	TransferSortByDomainNameDesc   TransferSortBy = "DOMAINNAME_DESC"
+	TransferSortByTransferDate     TransferSortBy = "TRANSFERDATE"
+	TransferSortByTransferDateDesc TransferSortBy = "TRANSFERDATE_DESC"
+	TransferSortByStatusDate       TransferSortBy = "STATUSDATE"
+	TransferSortByStatusDateDesc   TransferSortBy = "STATUSDATE_DESC"
+)
+
+// TransfersQuery holds the options of 'domains.transfer.getList'. Empty
+// fields are left to the API's defaults.
+type TransfersQuery struct {
+	ListType   TransferState
+	SearchTerm string // only transfers whose domain name contains it
+	SortBy     TransferSortBy
+	Page       int
+	PageSize   int // at most 100
+}
+
+func (query TransfersQuery) values() url.Values {
+	params := url.Values{}
+	if query.ListType != "" {
+		params.Set("ListType", string(query.ListType))
+	}
+	if query.SearchTerm != "" {
+		params.Set("SearchTerm", query.SearchTerm)
+	}
+	if query.SortBy != "" {
+		params.Set("SortBy", string(query.SortBy))
+	}
+	if query.Page > 0 {
+		params.Set("Page", strconv.Itoa(query.Page))
+	}
+	if query.PageSize > 0 {
+		params.Set("PageSize", strconv.Itoa(min(query.PageSize, maxPageSize)))
+	}
+	return params
+}
+
+// TransferCreate starts transferring a domain to the account, charging it
+// for years of registration.
+func (client *Client) TransferCreate(domainName string, years int, eppCode string, options ...TransferCreateOption) (*TransferCreateResult, error) {
+	return client.TransferCreateContext(context.Background(), domainName, years, eppCode, options...)
+}
+
+// TransferCreateContext is like TransferCreate but takes a context.
+func (client *Client) TransferCreateContext(ctx context.Context, domainName string, years int, eppCode string, options ...TransferCreateOption) (*TransferCreateResult, error) {
+	requestInfo := &ApiRequest{
+		command: transferCreate,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("DomainName", domainName)
+	requestInfo.params.Set("Years", strconv.Itoa(years))
+	requestInfo.params.Set("EPPCode", eppCode)
+	for _, opt := range options {
+		if opt.PromotionCode != "" {
+			requestInfo.params.Set("PromotionCode", opt.PromotionCode)
+		}
+		if opt.AddFreeWhoisguard {
+			requestInfo.params.Set("AddFreeWhoisguard", "yes")
+		}
+		if opt.WGEnabled {
+			requestInfo.params.Set("WGenable", "yes")
+		}
+		if opt.IsPremiumDomain {
+			requestInfo.params.Set("IsPremiumDomain", "true")
+			requestInfo.params.Set("PremiumPrice", opt.PremiumPrice.Amount())
+		}
+	}
+
+	var result struct {
+		TransferCreate *TransferCreateResult `xml:"DomainTransferCreateResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	if result.TransferCreate != nil {
+		result.TransferCreate.DomainName = unicodeDomain(result.TransferCreate.DomainName)
+	}
+	return result.TransferCreate, err
+}
+
+func (client *Client) TransferGetStatus(transferID int) (*TransferStatus, error) {
+	return client.TransferGetStatusContext(context.Background(), transferID)
+}
+
+// TransferGetStatusContext is like TransferGetStatus but takes a context.
+func (client *Client) TransferGetStatusContext(ctx context.Context, transferID int) (*TransferStatus, error) {
+	requestInfo := &ApiRequest{
+		command: transferGetStatus,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("TransferID", strconv.Itoa(transferID))
+
+	var result struct {
+		TransferStatus *TransferStatus `xml:"DomainTransferGetStatusResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	return result.TransferStatus, err
+}
+
+// TransferUpdateStatus resubmits a transfer once the issue holding it up has
+// been resolved. If eppCode is not empty, it replaces the EPP code the
+// transfer was created with.
+func (client *Client) TransferUpdateStatus(transferID int, eppCode string) (*TransferUpdateStatusResult, error) {
+	return client.TransferUpdateStatusContext(context.Background(), transferID, eppCode)
+}
+
+// TransferUpdateStatusContext is like TransferUpdateStatus but takes a context.
+func (client *Client) TransferUpdateStatusContext(ctx context.Context, transferID int, eppCode string) (*TransferUpdateStatusResult, error) {
+	requestInfo := &ApiRequest{
+		command: transferUpdateStatus,
+		method:  "POST",
+		params:  url.Values{},
+	}
+	requestInfo.params.Set("TransferID", strconv.Itoa(transferID))
+	requestInfo.params.Set("Resubmit", "true")
+	if eppCode != "" {
+		requestInfo.params.Set("EPPCode", eppCode)
+	}
+
+	var result struct {
+		TransferUpdateStatus *TransferUpdateStatusResult `xml:"DomainTransferUpdateStatusResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, err
+	}
+
+	return result.TransferUpdateStatus, err
+}
+
+// TransferGetList returns a page of the transfers matching query.
+func (client *Client) TransferGetList(query TransfersQuery) ([]TransferGetListResult, *Paging, error) {
+	return client.TransferGetListContext(context.Background(), query)
+}
+
+// TransferGetListContext is like TransferGetList but takes a context.
+func (client *Client) TransferGetListContext(ctx context.Context, query TransfersQuery) ([]TransferGetListResult, *Paging, error) {
+	requestInfo := &ApiRequest{
+		command: transferGetList,
+		method:  "POST",
+		params:  query.values(),
+	}
+
+	var result struct {
+		Transfers []TransferGetListResult `xml:"TransferGetListResult>Transfer"`
+		Paging    *Paging                 `xml:"Paging"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
+		return nil, nil, err
+	}
+
+	for i := range result.Transfers {
+		result.Transfers[i].DomainName = unicodeDomain(result.Transfers[i].DomainName)
+	}
+	return result.Transfers, result.Paging, err
+}
+
+// Transfers returns an iterator over every transfer matching query, ignoring
+// its Page and PageSize, like Domains.
+func (client *Client) Transfers(ctx context.Context, query TransfersQuery) iter.Seq2[TransferGetListResult, error] {
+	return paginate(func(page int) ([]TransferGetListResult, *Paging, error) {
+		query.Page, query.PageSize = page, maxPageSize
+		return client.TransferGetListContext(ctx, query)
+	})
+}
@@ -0,0 +1,165 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestTransferCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	respXML := `<?xml version="1.0" encoding="UTF-8"?>
+<ApiResponse xmlns="http://api.namecheap.com/xml.response" Status="OK">
+  <Errors />
+  <RequestedCommand>namecheap.domains.transfer.create</RequestedCommand>
+  <CommandResponse Type="namecheap.domains.transfer.create">
+    <DomainTransferCreateResult DomainName="domain1.com" Transfer="true" TransferID="15" StatusID="-22" OrderID="1234" TransactionID="5678" ChargedAmount="9.6900" />
+  </CommandResponse>
+</ApiResponse>`
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		correctParams := fillDefaultParams(url.Values{})
+		correctParams.Set("Command", "namecheap.domains.transfer.create")
+		correctParams.Set("DomainName", "domain1.com")
+		correctParams.Set("Years", "1")
+		correctParams.Set("EPPCode", "s3cr3t")
+		correctParams.Set("PromotionCode", "MOVE")
+		correctParams.Set("AddFreeWhoisguard", "yes")
+		correctParams.Set("WGenable", "yes")
+		testBody(t, r, correctParams)
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, respXML)
+	})
+
+	result, err := client.TransferCreate("domain1.com", 1, "s3cr3t", TransferCreateOption{
+		PromotionCode:     "MOVE",
+		AddFreeWhoisguard: true,
+		WGEnabled:         true,
+	})
+	if err != nil {
+		t.Fatalf("TransferCreate returned error: %v", err)
+	}
+	want := &TransferCreateResult{
+		DomainName:    "domain1.com",
+		Transfer:      true,
+		TransferID:    15,
+		StatusID:      TransferStatusWaitingEPPCode,
+		OrderID:       1234,
+		TransactionID: 5678,
+		ChargedAmount: mustMoney("9.69"),
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("TransferCreate returned %+v, want %+v", result, want)
+	}
+	if !result.StatusID.NeedsAction() {
+		t.Errorf("Expected status %d to need action", result.StatusID)
+	}
+}
+
+func TestTransferGetStatusAndUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var correctParams url.Values
+	var respXML string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, correctParams)
+		fmt.Fprint(w, respXML)
+	})
+
+	correctParams = fillDefaultParams(url.Values{})
+	correctParams.Set("Command", "namecheap.domains.transfer.getStatus")
+	correctParams.Set("TransferID", "15")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.transfer.getStatus">
+		<DomainTransferGetStatusResult TransferID="15" Status="Cancelled" StatusID="-1" />
+	</CommandResponse></ApiResponse>`
+	status, err := client.TransferGetStatus(15)
+	if err != nil {
+		t.Fatalf("TransferGetStatus returned error: %v", err)
+	}
+	if want := (&TransferStatus{TransferID: 15, Status: "Cancelled", StatusID: TransferStatusCancelled}); !reflect.DeepEqual(status, want) {
+		t.Errorf("TransferGetStatus returned %+v, want %+v", status, want)
+	}
+	if status.State() != TransferStateCancelled {
+		t.Errorf("State = %v, want %v", status.State(), TransferStateCancelled)
+	}
+
+	correctParams = fillDefaultParams(url.Values{})
+	correctParams.Set("Command", "namecheap.domains.transfer.updateStatus")
+	correctParams.Set("TransferID", "15")
+	correctParams.Set("Resubmit", "true")
+	correctParams.Set("EPPCode", "n3w")
+	respXML = `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.transfer.updateStatus">
+		<DomainTransferUpdateStatusResult TransferID="15" Resubmit="true" />
+	</CommandResponse></ApiResponse>`
+	update, err := client.TransferUpdateStatus(15, "n3w")
+	if err != nil {
+		t.Fatalf("TransferUpdateStatus returned error: %v", err)
+	}
+	if want := (&TransferUpdateStatusResult{TransferID: 15, Resubmit: true}); !reflect.DeepEqual(update, want) {
+		t.Errorf("TransferUpdateStatus returned %+v, want %+v", update, want)
+	}
+}
+
+func TestTransferState(t *testing.T) {
+	cases := []struct {
+		status TransferStatus
+		want   TransferState
+	}{
+		{TransferStatus{StatusID: TransferStatusCompleted, Status: "Transferred"}, TransferStateCompleted},
+		{TransferStatus{StatusID: TransferStatusCancelled, Status: "Cancelled"}, TransferStateCancelled},
+		{TransferStatus{StatusID: TransferStatusWaitingEPPCode, Status: "Waiting for EPP code"}, TransferStateInProgress},
+		// Unknown IDs are in progress, whatever the Status text says.
+		{TransferStatus{StatusID: 37, Status: "Transfer cancelled by the losing registrar"}, TransferStateInProgress},
+		{TransferStatus{StatusID: 3, Status: "Incomplete - awaiting approval"}, TransferStateInProgress},
+	}
+	for _, c := range cases {
+		if got := c.status.State(); got != c.want {
+			t.Errorf("State of %+v = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	const total = 150
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("ListType") != "INPROGRESS" || r.FormValue("SortBy") != "TRANSFERDATE_DESC" {
+			t.Errorf("ListType, SortBy = %v, %v", r.FormValue("ListType"), r.FormValue("SortBy"))
+		}
+		page, _ := strconv.Atoi(r.FormValue("Page"))
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.transfer.getList"><TransferGetListResult>`)
+		for i := (page - 1) * 100; i < page*100 && i < total; i++ {
+			fmt.Fprintf(w, `<Transfer ID="%d" DomainName="domain%d.com" User="anUser" TransferDate="06/18/2013" OrderID="%d" StatusID="-22" Status="Waiting for EPP code" StatusDate="06/19/2013" StatusDescription="" />`, i, i, i)
+		}
+		fmt.Fprintf(w, `</TransferGetListResult><Paging><TotalItems>%d</TotalItems><CurrentPage>%d</CurrentPage><PageSize>100</PageSize></Paging></CommandResponse></ApiResponse>`, total, page)
+	})
+
+	n := 0
+	for transfer, err := range client.Transfers(context.Background(), TransfersQuery{
+		ListType: TransferStateInProgress,
+		SortBy:   TransferSortByTransferDateDesc,
+	}) {
+		if err != nil {
+			t.Fatalf("Transfers yielded error: %v", err)
+		}
+		if transfer.ID != n || transfer.State() != TransferStateInProgress {
+			t.Errorf("Transfer %d = %+v", n, transfer)
+		}
+		if transfer.StatusDate.String() != "06/19/2013" {
+			t.Errorf("StatusDate = %v, want 06/19/2013", transfer.StatusDate)
+		}
+		n++
+	}
+	if n != total {
+		t.Errorf("Transfers yielded %d transfers, want %d", n, total)
+	}
+}
@@ -0,0 +1,241 @@
+package namecheap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultTransferWatchInterval is how often a TransferWatcher polls when its
+// Interval is zero. Transfers take days, so there is no point polling often.
+const DefaultTransferWatchInterval = 15 * time.Minute
+
+// TransferEvent reports a change in the status of a watched transfer, or a
+// failure to poll it.
+type TransferEvent struct {
+	TransferID int
+
+	// Previous is the last status seen, nil the first time the transfer is
+	// polled.
+	Previous *TransferStatus
+	Status   *TransferStatus
+
+	// Err is set, and Status nil, when the status could not be fetched or
+	// the progress could not be saved. The watcher tries again at the next
+	// poll, unless the API rejected the transfer itself, see Done.
+	Err error
+}
+
+// Done reports whether the transfer is no longer watched: it has reached a
+// terminal state, or the API rejected the transfer itself, such as for an
+// unknown transfer ID. Errors about the account, such as ErrAuthentication
+// or ErrIPNotWhitelisted, are tried again at the next poll.
+func (event *TransferEvent) Done() bool {
+	if event.Status == nil {
+		return errors.Is(event.Err, ErrInvalidParameter) ||
+			errors.Is(event.Err, ErrDomainNotFound) ||
+			errors.Is(event.Err, ErrDomainNotOwned)
+	}
+	return event.Status.State() != TransferStateInProgress
+}
+
+// TransferStore persists the progress of a TransferWatcher: the transfers it
+// watches and the last status seen of each, nil until one is.
+type TransferStore interface {
+	LoadTransfers() (map[int]*TransferStatus, error)
+	SaveTransfers(map[int]*TransferStatus) error
+}
+
+// FileTransferStore is a TransferStore keeping the progress in a JSON file.
+type FileTransferStore struct {
+	Path string
+}
+
+// LoadTransfers returns the progress saved in the file, or nothing if the
+// file does not exist yet.
+func (store *FileTransferStore) LoadTransfers() (map[int]*TransferStatus, error) {
+	data, err := os.ReadFile(store.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[int]*TransferStatus{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	transfers := map[int]*TransferStatus{}
+	if err := json.Unmarshal(data, &transfers); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// SaveTransfers replaces the file atomically, so an interrupted save keeps
+// the previous progress.
+func (store *FileTransferStore) SaveTransfers(transfers map[int]*TransferStatus) error {
+	data, err := json.MarshalIndent(transfers, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(store.Path), filepath.Base(store.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), store.Path)
+}
+
+// TransferWatcher polls the status of transfers until they complete, are
+// cancelled or are rejected by the API, reporting every change:
+//
+//	watcher := &namecheap.TransferWatcher{
+//		Client: client,
+//		Store:  &namecheap.FileTransferStore{Path: "transfers.json"},
+//	}
+//	events, err := watcher.Watch(ctx, transferIDs...)
+//	if err != nil { ... }
+//	for event := range events {
+//		...
+//	}
+type TransferWatcher struct {
+	Client *Client
+
+	// Interval is the time between polls, DefaultTransferWatchInterval if
+	// zero. Each poll makes one call per transfer still in progress.
+	Interval time.Duration
+
+	// Store, if set, keeps the progress across restarts: the transfers it
+	// holds are watched along with the ones passed to Watch, and their
+	// statuses are only reported again when they change.
+	Store TransferStore
+}
+
+// Watch starts polling transferIDs and the transfers in Store, the first
+// time right away. It returns a channel receiving the events, which is
+// closed once every transfer is done or ctx is done.
+// The progress is saved after the events of each poll are received and when
+// ctx is done, so a process killed in between reports them again once
+// restarted. An event that was not received does not count as seen.
+func (watcher *TransferWatcher) Watch(ctx context.Context, transferIDs ...int) (<-chan TransferEvent, error) {
+	transfers := map[int]*TransferStatus{}
+	if watcher.Store != nil {
+		loaded, err := watcher.Store.LoadTransfers()
+		if err != nil {
+			return nil, err
+		}
+		transfers = loaded
+	}
+	added := false
+	for _, id := range transferIDs {
+		if _, ok := transfers[id]; !ok {
+			transfers[id] = nil
+			added = true
+		}
+	}
+	if added && watcher.Store != nil {
+		if err := watcher.Store.SaveTransfers(transfers); err != nil {
+			return nil, err
+		}
+	}
+
+	events := make(chan TransferEvent)
+	go func() {
+		defer close(events)
+		watcher.run(ctx, transfers, events)
+	}()
+	return events, nil
+}
+
+func (watcher *TransferWatcher) run(ctx context.Context, transfers map[int]*TransferStatus, events chan<- TransferEvent) {
+	interval := watcher.Interval
+	if interval <= 0 {
+		interval = DefaultTransferWatchInterval
+	}
+	changed := false
+	defer func() {
+		// Keep what was seen before ctx was done; there is no one left to
+		// report a failure to.
+		if changed && watcher.Store != nil {
+			watcher.Store.SaveTransfers(transfers)
+		}
+	}()
+	send := func(event TransferEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for len(transfers) > 0 {
+		ids := make([]int, 0, len(transfers))
+		for id := range transfers {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+
+		for _, id := range ids {
+			previous := transfers[id]
+			status, err := watcher.Client.TransferGetStatusContext(ctx, id)
+			if ctx.Err() != nil {
+				return
+			}
+			if !failed(err) {
+				// Warnings do not change the status that was fetched.
+				err = nil
+				if status == nil {
+					err = errors.New("namecheap: empty transfer status")
+				}
+			}
+			if err != nil {
+				event := TransferEvent{TransferID: id, Previous: previous, Err: err}
+				if !send(event) {
+					return
+				}
+				if event.Done() {
+					delete(transfers, id)
+					changed = true
+				}
+				continue
+			}
+			if previous != nil && *previous == *status {
+				continue
+			}
+
+			event := TransferEvent{TransferID: id, Previous: previous, Status: status}
+			if !send(event) {
+				return
+			}
+			if event.Done() {
+				delete(transfers, id)
+			} else {
+				transfers[id] = status
+			}
+			changed = true
+		}
+
+		if changed && watcher.Store != nil {
+			if err := watcher.Store.SaveTransfers(transfers); err != nil {
+				if !send(TransferEvent{Err: err}) {
+					return
+				}
+			} else {
+				changed = false
+			}
+		}
+		if len(transfers) == 0 || sleepContext(ctx, interval) != nil {
+			return
+		}
+	}
+}
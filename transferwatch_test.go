@@ -0,0 +1,187 @@
+package namecheap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTransferWatcher(t *testing.T) {
+	setup()
+	defer teardown()
+
+	type status struct {
+		id   TransferStatusID
+		text string
+	}
+	waiting := status{TransferStatusWaitingEPPCode, "Waiting for EPP code"}
+
+	var mu sync.Mutex
+	statuses := map[int]status{1: waiting, 2: waiting}
+	setStatus := func(transferID int, s status) {
+		mu.Lock()
+		statuses[transferID] = s
+		mu.Unlock()
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		transferID, _ := strconv.Atoi(r.FormValue("TransferID"))
+		mu.Lock()
+		s, ok := statuses[transferID]
+		mu.Unlock()
+		if !ok {
+			fmt.Fprint(w, `<ApiResponse Status="ERROR"><Errors><Error Number="2011166">TransferID is invalid</Error></Errors></ApiResponse>`)
+			return
+		}
+		fmt.Fprintf(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.transfer.getStatus">
+			<DomainTransferGetStatusResult TransferID="%d" Status="%s" StatusID="%d" />
+		</CommandResponse></ApiResponse>`, transferID, s.text, s.id)
+	})
+
+	store := &FileTransferStore{Path: filepath.Join(t.TempDir(), "transfers.json")}
+	watcher := &TransferWatcher{Client: client, Interval: 10 * time.Millisecond, Store: store}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := watcher.Watch(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		event := <-events
+		if event.Err != nil || event.Previous != nil || event.Status.StatusID != TransferStatusWaitingEPPCode {
+			t.Fatalf("Unexpected first event %+v", event)
+		}
+	}
+	// Completed by its ID, although its text does not say so.
+	setStatus(1, status{TransferStatusCompleted, "Transferred"})
+	event := <-events
+	if event.TransferID != 1 || event.Previous.StatusID != TransferStatusWaitingEPPCode || !event.Done() {
+		t.Fatalf("Unexpected event %+v, want transfer 1 completed", event)
+	}
+	cancel()
+	for range events {
+	}
+
+	saved, err := store.LoadTransfers()
+	if err != nil {
+		t.Fatalf("LoadTransfers returned error: %v", err)
+	}
+	if len(saved) != 1 || saved[2] == nil || saved[2].StatusID != TransferStatusWaitingEPPCode {
+		t.Fatalf("Saved progress %+v, want transfer 2 waiting", saved)
+	}
+
+	// A new watcher resumes with transfer 2, reporting only its change, and
+	// drops transfer 3 which the API does not know.
+	setStatus(2, status{TransferStatusCancelled, "Cancelled"})
+	events, err = watcher.Watch(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	var resumed []TransferEvent
+	for event := range events {
+		resumed = append(resumed, event)
+	}
+	if len(resumed) != 2 {
+		t.Fatalf("Resumed watcher sent %+v, want 2 events", resumed)
+	}
+	if resumed[0].TransferID != 2 || resumed[0].Previous.StatusID != TransferStatusWaitingEPPCode ||
+		resumed[0].Status.State() != TransferStateCancelled {
+		t.Errorf("Resumed watcher sent %+v, want transfer 2 cancelled", resumed[0])
+	}
+	if resumed[1].TransferID != 3 || resumed[1].Err == nil || !resumed[1].Done() {
+		t.Errorf("Resumed watcher sent %+v, want transfer 3 rejected", resumed[1])
+	}
+	if saved, _ := store.LoadTransfers(); len(saved) != 0 {
+		t.Errorf("Saved progress %+v, want nothing left to watch", saved)
+	}
+}
+
+func TestTransferWatcherKeepsAccountErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<ApiResponse Status="ERROR"><Errors><Error Number="1011102">Parameter APIKey is invalid</Error></Errors></ApiResponse>`)
+	})
+
+	waiting := &TransferStatus{TransferID: 1, Status: "Waiting for EPP code", StatusID: TransferStatusWaitingEPPCode}
+	store := &FileTransferStore{Path: filepath.Join(t.TempDir(), "transfers.json")}
+	if err := store.SaveTransfers(map[int]*TransferStatus{1: waiting}); err != nil {
+		t.Fatalf("SaveTransfers returned error: %v", err)
+	}
+	watcher := &TransferWatcher{Client: client, Interval: 10 * time.Millisecond, Store: store}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := watcher.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	// The transfer is polled again after an authentication error.
+	for i := 0; i < 2; i++ {
+		event := <-events
+		if !errors.Is(event.Err, ErrAuthentication) || event.Done() {
+			t.Fatalf("Unexpected event %+v, want a retryable authentication error", event)
+		}
+	}
+	cancel()
+	for range events {
+	}
+
+	saved, err := store.LoadTransfers()
+	if err != nil {
+		t.Fatalf("LoadTransfers returned error: %v", err)
+	}
+	if len(saved) != 1 || saved[1] == nil || *saved[1] != *waiting {
+		t.Errorf("Saved progress %+v, want transfer 1 still waiting", saved)
+	}
+}
+
+func TestTransferWatcherCancelBeforeReceive(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polled := make(chan struct{}, 1)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<ApiResponse Status="OK"><CommandResponse Type="namecheap.domains.transfer.getStatus">
+			<DomainTransferGetStatusResult TransferID="1" Status="Transferred" StatusID="5" />
+		</CommandResponse></ApiResponse>`)
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+
+	waiting := &TransferStatus{TransferID: 1, Status: "Waiting for EPP code", StatusID: TransferStatusWaitingEPPCode}
+	store := &FileTransferStore{Path: filepath.Join(t.TempDir(), "transfers.json")}
+	if err := store.SaveTransfers(map[int]*TransferStatus{1: waiting}); err != nil {
+		t.Fatalf("SaveTransfers returned error: %v", err)
+	}
+	watcher := &TransferWatcher{Client: client, Store: store}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := watcher.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch returned error: %v", err)
+	}
+	// Cancel while the completion is waiting to be received.
+	<-polled
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	for event := range events {
+		t.Fatalf("Unexpected event %+v after cancel", event)
+	}
+
+	saved, err := store.LoadTransfers()
+	if err != nil {
+		t.Fatalf("LoadTransfers returned error: %v", err)
+	}
+	if len(saved) != 1 || saved[1] == nil || *saved[1] != *waiting {
+		t.Errorf("Saved progress %+v, want the unreceived completion not to be saved", saved)
+	}
+}
@@ -1,6 +1,7 @@
 package namecheap
 
 import (
+	"context"
 	"net/url"
 )
 
@@ -16,28 +17,70 @@ type UsersGetPricingResult struct {
 		Product []struct {
 			Name  string `xml:"Name,attr"`
 			Price []struct {
-				Duration     int     `xml:"Duration,attr"`
-				DurationType string  `xml:"DurationType,attr"`
-				Price        float64 `xml:"Price,attr"`
-				RegularPrice float64 `xml:"RegularPrice,attr"`
-				YourPrice    float64 `xml:"YourPrice,attr"`
-				CouponPrice  float64 `xml:"CouponPrice,attr"`
-				Currency     string  `xml:"Currency,attr"`
+				Duration     int    `xml:"Duration,attr"`
+				DurationType string `xml:"DurationType,attr"`
+				Price        Money  `xml:"Price,attr"`
+				RegularPrice Money  `xml:"RegularPrice,attr"`
+				YourPrice    Money  `xml:"YourPrice,attr"`
+				CouponPrice  Money  `xml:"CouponPrice,attr"`
+				Currency     string `xml:"Currency,attr"`
 			} `xml:"Price"`
 		} `xml:"Product"`
 	} `xml:"ProductCategory"`
 }
 
 type UsersGetBalancesResult struct {
-	Currency                  string  `xml:"Currency,attr"`
-	AvailableBalance          float64 `xml:"AvailableBalance,attr"`
-	AccountBalance            float64 `xml:"AccountBalance,attr"`
-	EarnedAmount              float64 `xml:"EarnedAmount,attr"`
-	WithdrawableAmount        float64 `xml:"WithdrawableAmount,attr"`
-	FundsRequiredForAutoRenew float64 `xml:"FundsRequiredForAutoRenew,attr"`
+	Currency                  string `xml:"Currency,attr"`
+	AvailableBalance          Money  `xml:"AvailableBalance,attr"`
+	AccountBalance            Money  `xml:"AccountBalance,attr"`
+	EarnedAmount              Money  `xml:"EarnedAmount,attr"`
+	WithdrawableAmount        Money  `xml:"WithdrawableAmount,attr"`
+	FundsRequiredForAutoRenew Money  `xml:"FundsRequiredForAutoRenew,attr"`
+}
+
+// setCurrency applies the Currency attribute of each price to its amounts,
+// which are decoded before it is known. Amounts of a price without one keep
+// DefaultCurrency.
+func (result *UsersGetPricingResult) setCurrency() {
+	for _, category := range result.ProductCategory {
+		for _, product := range category.Product {
+			for i := range product.Price {
+				price := &product.Price[i]
+				if price.Currency == "" {
+					continue
+				}
+				price.Price.Currency = price.Currency
+				price.RegularPrice.Currency = price.Currency
+				price.YourPrice.Currency = price.Currency
+				price.CouponPrice.Currency = price.Currency
+			}
+		}
+	}
+}
+
+// setCurrency applies the Currency attribute to the balances, which are
+// decoded before it is known. Without one they keep DefaultCurrency.
+func (result *UsersGetBalancesResult) setCurrency() {
+	if result.Currency == "" {
+		return
+	}
+	for _, m := range []*Money{
+		&result.AvailableBalance,
+		&result.AccountBalance,
+		&result.EarnedAmount,
+		&result.WithdrawableAmount,
+		&result.FundsRequiredForAutoRenew,
+	} {
+		m.Currency = result.Currency
+	}
 }
 
 func (client *Client) UsersGetPricing(productType string) ([]UsersGetPricingResult, error) {
+	return client.UsersGetPricingContext(context.Background(), productType)
+}
+
+// UsersGetPricingContext is like UsersGetPricing but takes a context.
+func (client *Client) UsersGetPricingContext(ctx context.Context, productType string) ([]UsersGetPricingResult, error) {
 	requestInfo := &ApiRequest{
 		command: usersGetPricing,
 		method:  "POST",
@@ -45,25 +88,42 @@ func (client *Client) UsersGetPricing(productType string) ([]UsersGetPricingResu
 	}
 
 	requestInfo.params.Set("ProductType", productType)
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		UsersGetPricing []UsersGetPricingResult `xml:"UserGetPricingResult>ProductType"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
+	for i := range result.UsersGetPricing {
+		result.UsersGetPricing[i].setCurrency()
+	}
 
-	return resp.UsersGetPricing, nil
+	return result.UsersGetPricing, err
 }
 
 func (client *Client) UsersGetBalances() ([]UsersGetBalancesResult, error) {
+	return client.UsersGetBalancesContext(context.Background())
+}
+
+// UsersGetBalancesContext is like UsersGetBalances but takes a context.
+func (client *Client) UsersGetBalancesContext(ctx context.Context) ([]UsersGetBalancesResult, error) {
 	requestInfo := &ApiRequest{
 		command: usersGetBalances,
 		method:  "POST",
 		params:  url.Values{},
 	}
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		UsersGetBalances []UsersGetBalancesResult `xml:"UserGetBalancesResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
+	for i := range result.UsersGetBalances {
+		result.UsersGetBalances[i].setCurrency()
+	}
 
-	return resp.UsersGetBalances, nil
+	return result.UsersGetBalances, err
 }
@@ -1,6 +1,7 @@
 package namecheap
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"strconv"
@@ -16,8 +17,8 @@ const (
 type WhoisguardGetListResult struct {
 	ID         int64  `xml:"ID,attr"`
 	DomainName string `xml:"DomainName,attr"`
-	Created    string `xml:"Created,attr"`
-	Expires    string `xml:"Expires,attr"`
+	Created    Date   `xml:"Created,attr"`
+	Expires    Date   `xml:"Expires,attr"`
 	Status     string `xml:"Status,attr"`
 }
 
@@ -32,29 +33,45 @@ type whoisguardDisableResult struct {
 }
 
 type WhoisguardRenewResult struct {
-	WhoisguardID  int64   `xml:"WhoisguardId,attr"`
-	Renewed       bool    `xml:"Renew,attr"`
-	ChargedAmount float64 `xml:"ChargedAmount,attr"`
-	OrderID       int     `xml:"OrderId,attr"`
-	TransactionID int     `xml:"TransactionId,attr"`
+	WhoisguardID  int64 `xml:"WhoisguardId,attr"`
+	Renewed       bool  `xml:"Renew,attr"`
+	ChargedAmount Money `xml:"ChargedAmount,attr"`
+	OrderID       int   `xml:"OrderId,attr"`
+	TransactionID int   `xml:"TransactionId,attr"`
 }
 
 func (client *Client) WhoisguardGetList() ([]WhoisguardGetListResult, error) {
+	return client.WhoisguardGetListContext(context.Background())
+}
+
+// WhoisguardGetListContext is like WhoisguardGetList but takes a context.
+func (client *Client) WhoisguardGetListContext(ctx context.Context) ([]WhoisguardGetListResult, error) {
 	requestInfo := &ApiRequest{
 		command: whoisguardGetList,
 		method:  "POST",
 		params:  url.Values{},
 	}
 
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		WhoisguardList []WhoisguardGetListResult `xml:"WhoisguardGetListResult>Whoisguard"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.WhoisguardList, nil
+	for i := range result.WhoisguardList {
+		result.WhoisguardList[i].DomainName = unicodeDomain(result.WhoisguardList[i].DomainName)
+	}
+	return result.WhoisguardList, err
 }
 
 func (client *Client) WhoisguardEnable(id int64, email string) error {
+	return client.WhoisguardEnableContext(context.Background(), id, email)
+}
+
+// WhoisguardEnableContext is like WhoisguardEnable but takes a context.
+func (client *Client) WhoisguardEnableContext(ctx context.Context, id int64, email string) error {
 	requestInfo := &ApiRequest{
 		command: whoisguardEnable,
 		method:  "POST",
@@ -63,8 +80,11 @@ func (client *Client) WhoisguardEnable(id int64, email string) error {
 
 	requestInfo.params.Set("WhoisguardID", strconv.FormatInt(id, 10))
 	requestInfo.params.Set("ForwardedToEmail", email)
-	resp, err := client.do(requestInfo)
-	if err == nil && !resp.WhoisguardEnable.IsSuccess {
+	var result struct {
+		WhoisguardEnable whoisguardEnableResult `xml:"WhoisguardEnableResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if err == nil && !result.WhoisguardEnable.IsSuccess {
 		err = errors.New("IsSuccess was false")
 	}
 
@@ -72,6 +92,11 @@ func (client *Client) WhoisguardEnable(id int64, email string) error {
 }
 
 func (client *Client) WhoisguardDisable(id int64) error {
+	return client.WhoisguardDisableContext(context.Background(), id)
+}
+
+// WhoisguardDisableContext is like WhoisguardDisable but takes a context.
+func (client *Client) WhoisguardDisableContext(ctx context.Context, id int64) error {
 	requestInfo := &ApiRequest{
 		command: whoisguardDisable,
 		method:  "POST",
@@ -79,8 +104,11 @@ func (client *Client) WhoisguardDisable(id int64) error {
 	}
 
 	requestInfo.params.Set("WhoisguardID", strconv.FormatInt(id, 10))
-	resp, err := client.do(requestInfo)
-	if err == nil && !resp.WhoisguardDisable.IsSuccess {
+	var result struct {
+		WhoisguardDisable whoisguardDisableResult `xml:"WhoisguardDisableResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if err == nil && !result.WhoisguardDisable.IsSuccess {
 		err = errors.New("IsSuccess was false")
 	}
 
@@ -88,6 +116,11 @@ func (client *Client) WhoisguardDisable(id int64) error {
 }
 
 func (client *Client) WhoisguardRenew(id int64, years int) (*WhoisguardRenewResult, error) {
+	return client.WhoisguardRenewContext(context.Background(), id, years)
+}
+
+// WhoisguardRenewContext is like WhoisguardRenew but takes a context.
+func (client *Client) WhoisguardRenewContext(ctx context.Context, id int64, years int) (*WhoisguardRenewResult, error) {
 	requestInfo := &ApiRequest{
 		command: whoisguardRenew,
 		method:  "POST",
@@ -96,10 +129,13 @@ func (client *Client) WhoisguardRenew(id int64, years int) (*WhoisguardRenewResu
 
 	requestInfo.params.Set("WhoisguardID", strconv.FormatInt(id, 10))
 	requestInfo.params.Set("Years", strconv.Itoa(years))
-	resp, err := client.do(requestInfo)
-	if err != nil {
+	var result struct {
+		WhoisguardRenew *WhoisguardRenewResult `xml:"WhoisguardRenewResult"`
+	}
+	_, err := client.do(ctx, requestInfo, &result)
+	if failed(err) {
 		return nil, err
 	}
 
-	return resp.WhoisguardRenew, nil
+	return result.WhoisguardRenew, err
 }
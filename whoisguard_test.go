@@ -50,15 +50,15 @@ func TestWhoisguardGetList(t *testing.T) {
 	want := []WhoisguardGetListResult{
 		WhoisguardGetListResult{
 			ID:      34401,
-			Created: "12/18/2013",
-			Expires: "12/18/2014",
+			Created: mustDate("12/18/2013"),
+			Expires: mustDate("12/18/2014"),
 			Status:  "unused",
 		},
 		WhoisguardGetListResult{
 			ID:         34400,
 			DomainName: "test.com",
-			Created:    "12/26/2013",
-			Expires:    "12/26/2014",
+			Created:    mustDate("12/26/2013"),
+			Expires:    mustDate("12/26/2014"),
 			Status:     "enabled",
 		},
 	}
@@ -169,7 +169,7 @@ func TestWhoisguardRenew(t *testing.T) {
 	want := &WhoisguardRenewResult{
 		WhoisguardID:  38495,
 		Renewed:       true,
-		ChargedAmount: 6.8,
+		ChargedAmount: mustMoney("6.8"),
 		TransactionID: 884255,
 		OrderID:       580938,
 	}